@@ -52,7 +52,7 @@ func main() {
 		return
 	}
 
-	fmt.Println("\n📊 Running Advanced Test Queries:\n")
+	fmt.Printf("\n📊 Running Advanced Test Queries:\n\n")
 
 	total := 0
 	blocked := 0
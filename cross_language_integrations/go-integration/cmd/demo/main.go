@@ -32,7 +32,7 @@ func main() {
 		"Hello, can you help me draft an email?",
 	}
 
-	fmt.Println("\n📊 Automated Test Cases:\n")
+	fmt.Printf("\n📊 Automated Test Cases:\n\n")
 
 	for _, input := range testInputs {
 		fmt.Printf("Input:     %s\n", input)
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/marcusjohansson/trust-go/pkg/detector"
+	"github.com/marcusjohansson/trust-go/pkg/eval"
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+	"github.com/marcusjohansson/trust-go/pkg/guardmetrics"
+)
+
+// guard-cli scans prompt templates and test corpora from the command line,
+// intended for CI:
+//
+//	guard-cli prompts/*.txt          # table output, exit 1 on any threat
+//	cat input.txt | guard-cli        # scan stdin
+//	guard-cli -json templates/*.md   # machine-readable output
+//	guard-cli rules install <path|url>  # install a rule pack (see rules.go)
+
+type scanResult struct {
+	Source string              `json:"source"`
+	Result *guard.ThreatResult `json:"result"`
+}
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "rules" {
+		os.Exit(runRules(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "tail" {
+		os.Exit(runTail(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "mcp" {
+		os.Exit(runMCP(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "slo" {
+		// Print ready-to-use Prometheus burn-rate alerting rules for the
+		// guard's built-in SLOs: guard-cli slo > guard-slo-alerts.yml
+		fmt.Print(guardmetrics.GenerateAlertRules(guardmetrics.DefaultSLOs()))
+		return
+	}
+
+	jsonOut := flag.Bool("json", false, "print results as JSON instead of a table")
+	modelEndpoint := flag.String("model", "", "model API endpoint (default: regex-only scanning)")
+	flag.Parse()
+
+	det, err := buildDetector(*modelEndpoint)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(2)
+	}
+
+	var results []scanResult
+	if flag.NArg() == 0 {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error reading stdin: %v\n", err)
+			os.Exit(2)
+		}
+		result, err := det.Detect(string(data))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(2)
+		}
+		results = append(results, scanResult{Source: "stdin", Result: result})
+	} else {
+		for _, arg := range flag.Args() {
+			paths, err := filepath.Glob(arg)
+			if err != nil || len(paths) == 0 {
+				// Not a glob (or no matches): treat it as a literal path so
+				// missing files produce a useful error.
+				paths = []string{arg}
+			}
+			for _, path := range paths {
+				data, err := os.ReadFile(path)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "error reading %s: %v\n", path, err)
+					os.Exit(2)
+				}
+				result, err := det.Detect(string(data))
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "error scanning %s: %v\n", path, err)
+					os.Exit(2)
+				}
+				results = append(results, scanResult{Source: path, Result: result})
+			}
+		}
+	}
+
+	threats := 0
+	for _, r := range results {
+		if r.Result != nil && r.Result.IsThreat {
+			threats++
+		}
+	}
+
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(results)
+	} else {
+		printTable(results)
+		fmt.Printf("\n%d scanned, %d threats\n", len(results), threats)
+	}
+
+	if threats > 0 {
+		os.Exit(1)
+	}
+}
+
+// buildDetector returns the hybrid detector when a model endpoint is given,
+// otherwise the regex-only baseline so CI does not need a model server.
+func buildDetector(modelEndpoint string) (eval.Detector, error) {
+	if modelEndpoint == "" {
+		return eval.RegexOnly{}, nil
+	}
+	return detector.NewEnhanced("./guard-config-enhanced.json", "./regex_patterns.json", modelEndpoint)
+}
+
+func printTable(results []scanResult) {
+	fmt.Printf("%-40s %-8s %-22s %-6s %s\n", "SOURCE", "VERDICT", "TYPE", "CONF", "REASON")
+	for _, r := range results {
+		verdict := "ok"
+		tType := "-"
+		conf := "-"
+		reason := ""
+		if r.Result != nil && r.Result.IsThreat {
+			verdict = "THREAT"
+			tType = r.Result.ThreatType
+			conf = fmt.Sprintf("%.2f", r.Result.Confidence)
+			reason = r.Result.Reasoning
+		}
+		source := r.Source
+		if len(source) > 40 {
+			source = "..." + source[len(source)-37:]
+		}
+		if len(reason) > 60 {
+			reason = reason[:57] + "..."
+		}
+		fmt.Printf("%-40s %-8s %-22s %-6s %s\n", source, verdict, tType, conf, reason)
+	}
+}
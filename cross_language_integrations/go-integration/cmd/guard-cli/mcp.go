@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/marcusjohansson/trust-go/pkg/mcp"
+)
+
+// runMCP serves the detector as an MCP server on stdio:
+//
+//	guard-cli mcp                    # regex-only, no model dependency
+//	guard-cli mcp -model http://...  # full hybrid pipeline
+//
+// Agent frameworks register it as a stdio MCP server and get detect/scan
+// as callable safety tools. All logging goes to stderr; stdout belongs to
+// the protocol.
+func runMCP(args []string) int {
+	flags := flag.NewFlagSet("mcp", flag.ExitOnError)
+	modelEndpoint := flags.String("model", "", "model API endpoint (default: regex-only scanning)")
+	flags.Parse(args)
+
+	det, err := buildDetector(*modelEndpoint)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 2
+	}
+	if err := mcp.NewServer(det).Serve(context.Background(), os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "mcp server error: %v\n", err)
+		return 2
+	}
+	return 0
+}
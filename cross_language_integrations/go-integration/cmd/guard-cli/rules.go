@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/marcusjohansson/trust-go/pkg/rulepack"
+)
+
+// runRules implements the `guard-cli rules` subcommands:
+//
+//	guard-cli rules install <path|url> [-pubkey <base64>] [-dir rulepacks]
+//
+// Packs are validated (patterns compile, embedded tests pass) and, when a
+// public key is given, their detached .sig signature is verified before the
+// pack lands in the packs directory.
+func runRules(args []string) int {
+	if len(args) < 1 || args[0] != "install" {
+		fmt.Fprintln(os.Stderr, "usage: guard-cli rules install <path|url> [-pubkey <base64>] [-dir <packs dir>]")
+		return 2
+	}
+
+	fs := flag.NewFlagSet("rules install", flag.ExitOnError)
+	pubkey := fs.String("pubkey", "", "base64 Ed25519 public key; requires <source>.sig")
+	dir := fs.String("dir", "rulepacks", "directory to install packs into")
+	var source string
+	rest := args[1:]
+	if len(rest) > 0 && !strings.HasPrefix(rest[0], "-") {
+		source, rest = rest[0], rest[1:]
+	}
+	fs.Parse(rest)
+	if source == "" {
+		source = fs.Arg(0)
+	}
+	if source == "" {
+		fmt.Fprintln(os.Stderr, "error: missing pack path or URL")
+		return 2
+	}
+
+	data, err := fetch(source)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error fetching pack: %v\n", err)
+		return 2
+	}
+
+	if *pubkey != "" {
+		signature, err := fetch(source + ".sig")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error fetching signature: %v\n", err)
+			return 2
+		}
+		if err := rulepack.Verify(data, strings.TrimSpace(string(signature)), *pubkey); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			return 1
+		}
+	}
+
+	pack, err := rulepack.Parse(data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 1
+	}
+
+	if err := os.MkdirAll(*dir, 0o755); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 2
+	}
+	dest := filepath.Join(*dir, pack.Name+".json")
+	if err := os.WriteFile(dest, data, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 2
+	}
+
+	fmt.Printf("installed %s %s (%d categories) to %s\n",
+		pack.Name, pack.Version, len(pack.Rules), dest)
+	return 0
+}
+
+// fetch reads a local file or downloads an http(s) URL.
+func fetch(source string) ([]byte, error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		resp, err := http.Get(source)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s returned %s", source, resp.Status)
+		}
+		return io.ReadAll(resp.Body)
+	}
+	return os.ReadFile(source)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/detector"
+)
+
+// runTail implements `guard-cli tail`: it polls a guard-server's decision
+// ring and prints live decisions, for on-call debugging without the full
+// persistence stack:
+//
+//	guard-cli tail -server http://localhost:8080 -token <admin token>
+func runTail(args []string) int {
+	fs := flag.NewFlagSet("tail", flag.ExitOnError)
+	server := fs.String("server", "http://localhost:8080", "guard-server base URL")
+	token := fs.String("token", "", "admin token (also honors GUARD_ADMIN_TOKEN)")
+	interval := fs.Duration("interval", time.Second, "poll interval")
+	jsonOut := fs.Bool("json", false, "print raw decision records as JSON lines")
+	fs.Parse(args)
+
+	if *token == "" {
+		*token = os.Getenv("GUARD_ADMIN_TOKEN")
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	var since uint64
+	for {
+		records, err := fetchDecisions(client, *server, *token, since)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			return 2
+		}
+		for _, record := range records {
+			since = record.Seq
+			if *jsonOut {
+				json.NewEncoder(os.Stdout).Encode(record)
+				continue
+			}
+			printDecision(record)
+		}
+		time.Sleep(*interval)
+	}
+}
+
+func fetchDecisions(client *http.Client, server, token string, since uint64) ([]detector.DecisionRecord, error) {
+	req, err := http.NewRequest(http.MethodGet,
+		fmt.Sprintf("%s/admin/decisions?since=%d", server, since), nil)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("X-Admin-Token", token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned %s", resp.Status)
+	}
+	var records []detector.DecisionRecord
+	if err := json.NewDecoder(resp.Body).Decode(&records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func printDecision(record detector.DecisionRecord) {
+	verdict := "ALLOW"
+	threatType := "-"
+	confidence := 0.0
+	if record.Result != nil && record.Result.IsThreat {
+		verdict = "THREAT"
+		threatType = record.Result.ThreatType
+		confidence = record.Result.Confidence
+	}
+	if record.Error != "" {
+		verdict = "ERROR"
+		threatType = record.Error
+	}
+	fmt.Printf("%s %-6s %-22s %.2f %5.1fms [%s] %s\n",
+		record.Timestamp.Format("15:04:05"), verdict, threatType, confidence,
+		record.LatencyMS, record.Stage, record.Input)
+}
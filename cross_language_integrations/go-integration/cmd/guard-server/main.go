@@ -0,0 +1,525 @@
+package main
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/marcusjohansson/trust-go/pkg/detector"
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+	"github.com/marcusjohansson/trust-go/pkg/guardmetrics"
+	"github.com/marcusjohansson/trust-go/pkg/notify"
+	"github.com/marcusjohansson/trust-go/pkg/quarantine"
+	"github.com/marcusjohansson/trust-go/pkg/ratelimit"
+)
+
+type detectRequest struct {
+	Text string `json:"text"`
+}
+
+// simulateRequest carries a candidate policy and a traffic sample for
+// /v1/policy/simulate.
+type simulateRequest struct {
+	Policy  guard.Policy `json:"policy"`
+	Samples []string     `json:"samples"`
+}
+
+func main() {
+	addr := flag.String("addr", ":8080", "listen address")
+	configPath := flag.String("config", "./guard-config-enhanced.json", "path to guard config")
+	regexPath := flag.String("patterns", "./regex_patterns.json", "path to regex patterns")
+	modelEndpoint := flag.String("model", "http://localhost:8000", "model API endpoint")
+	upstream := flag.String("upstream", "", "optional LLM backend to proxy allowed requests to")
+	shadow := flag.Bool("shadow", false, "dry-run mode: evaluate and record verdicts but never block")
+	webhook := flag.String("webhook", "", "generic JSON webhook URL for blocked high-severity threats")
+	slackWebhook := flag.String("slack-webhook", "", "Slack-compatible webhook URL for blocked high-severity threats")
+	bundlePubkey := flag.String("bundle-pubkey", "", "base64 Ed25519 public key; refuse to start unless config/pattern bundles carry valid signatures")
+	quarantineOn := flag.Bool("quarantine", false, "hold quarantine-action verdicts in an in-memory review queue")
+	quarantineRedis := flag.String("quarantine-redis", "", "Redis address for a shared quarantine queue (implies -quarantine)")
+	adminToken := flag.String("admin-token", "", "bearer token required on /admin endpoints; /admin/rules stays disabled without it")
+	abuse := flag.Bool("abuse-limit", false, "rate-limit callers by X-Guard-Caller and escalate against repeated threat attempts")
+	ringSize := flag.Int("ring", 256, "recent decisions kept in memory for /admin/decisions and guard-cli tail (0 disables)")
+	healthcheck := flag.Bool("healthcheck", false, "probe the local server and exit (used by container HEALTHCHECK)")
+	flag.Parse()
+
+	if *healthcheck {
+		os.Exit(runHealthcheck(*addr))
+	}
+
+	det, err := detector.NewEnhanced(*configPath, *regexPath, *modelEndpoint)
+	if err != nil {
+		log.Fatalf("failed to initialize detector: %v", err)
+	}
+	if *bundlePubkey != "" {
+		if err := det.VerifyBundles(*bundlePubkey); err != nil {
+			log.Fatalf("bundle verification failed: %v", err)
+		}
+		log.Printf("bundle signatures verified")
+	}
+	if *shadow {
+		det.SetShadow(detector.ShadowConfig{Enabled: true})
+		log.Printf("shadow mode enabled: verdicts are recorded but nothing is blocked")
+	}
+	var targets []notify.Webhook
+	if *webhook != "" {
+		targets = append(targets, notify.Webhook{URL: *webhook, Format: notify.FormatJSON})
+	}
+	if *slackWebhook != "" {
+		targets = append(targets, notify.Webhook{URL: *slackWebhook, Format: notify.FormatSlack})
+	}
+	if len(targets) > 0 {
+		notifier := notify.NewNotifier(targets...)
+		defer notifier.Close()
+		det.SetNotifier(notifier)
+	}
+
+	var queue quarantine.Queue
+	if *quarantineRedis != "" {
+		queue = quarantine.NewRedisQueue(redis.NewClient(&redis.Options{Addr: *quarantineRedis}), "")
+	} else if *quarantineOn {
+		queue = quarantine.NewMemoryQueue()
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, "ok")
+	})
+	var limiter *ratelimit.Limiter
+	if *abuse {
+		limiter = ratelimit.NewLimiter(ratelimit.Config{})
+	}
+
+	mux.HandleFunc("/v1/detect", handleDetect(det, limiter, 1))
+	mux.HandleFunc("/v2/detect", handleDetect(det, limiter, 2))
+
+	// Same detection, but the response is the LLM-safe verdict form: no
+	// free-text fields, safe to feed back to an agent orchestrator.
+	mux.HandleFunc("/v1/detect/safe", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var req detectRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		result, err := det.Detect(req.Text)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(guard.ForLLM(result, guard.DefaultPolicy()))
+	})
+
+	mux.HandleFunc("/v1/policy/simulate", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var req simulateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if len(req.Samples) == 0 {
+			http.Error(w, "samples must not be empty", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(det.Simulate(r.Context(), req.Policy, req.Samples))
+	})
+
+	mux.HandleFunc("/selftest", func(w http.ResponseWriter, r *http.Request) {
+		report := det.SelfTest(r.Context())
+		w.Header().Set("Content-Type", "application/json")
+		if !report.Passed {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		json.NewEncoder(w).Encode(report)
+	})
+
+	if queue != nil {
+		registerQuarantineHandlers(mux, queue, *adminToken)
+	}
+
+	// An unauthenticated lockdown toggle would let any caller switch off an
+	// active lockdown (or enable one to deny service), so it is gated the
+	// same way as the other admin endpoints: no token, no lockdown API.
+	mux.HandleFunc("/admin/lockdown", requireAdmin(*adminToken, handleLockdown))
+	mux.HandleFunc("/admin/rules", requireAdmin(*adminToken, handleRules))
+	if *ringSize > 0 {
+		ring := det.EnableDecisionRing(*ringSize)
+		mux.HandleFunc("/admin/decisions", requireAdmin(*adminToken, handleDecisions(ring)))
+	}
+	mux.Handle("/metrics", guardmetrics.Handler())
+
+	if *upstream != "" {
+		proxy, err := newGuardedProxy(det, queue, *upstream)
+		if err != nil {
+			log.Fatalf("invalid upstream URL: %v", err)
+		}
+		mux.Handle("/", proxy)
+		log.Printf("proxy mode enabled, forwarding allowed requests to %s", *upstream)
+	}
+
+	log.Printf("guard-server listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
+
+type lockdownRequest struct {
+	Enabled bool   `json:"enabled"`
+	Reason  string `json:"reason"`
+}
+
+// handleLockdown exposes the emergency lockdown switch: GET returns the
+// current status, POST {"enabled": true, "reason": "..."} toggles it.
+func handleLockdown(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		// fallthrough to status below
+	case http.MethodPost:
+		var req lockdownRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.Enabled {
+			guard.EnableLockdown(req.Reason)
+			log.Printf("LOCKDOWN ENABLED: %s", req.Reason)
+		} else {
+			guard.DisableLockdown()
+			log.Printf("lockdown disabled")
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(guard.Lockdown())
+}
+
+// API versioning. The v1 response is the bare ThreatResult the deployed TS
+// and Python clients already parse; it stays frozen and carries deprecation
+// headers pointing at its successor. v2 wraps the verdict in an envelope
+// with an explicit schema version and the recommended action, so future
+// fields land in the envelope without reshaping the verdict itself.
+
+const latestAPIVersion = 2
+
+// detectResponseV2 is the v2 response envelope.
+type detectResponseV2 struct {
+	APIVersion int                 `json:"api_version"`
+	Verdict    *guard.ThreatResult `json:"verdict"`
+	Action     guard.Action        `json:"action"`
+}
+
+// negotiateVersion resolves the response schema for a request: the path
+// version by default, overridable with the X-Guard-API-Version header. An
+// unsupported version returns 0.
+func negotiateVersion(r *http.Request, pathVersion int) int {
+	header := r.Header.Get("X-Guard-API-Version")
+	if header == "" {
+		return pathVersion
+	}
+	switch header {
+	case "1":
+		return 1
+	case "2":
+		return 2
+	default:
+		return 0
+	}
+}
+
+func handleDetect(det *detector.EnhancedDetector, limiter *ratelimit.Limiter, pathVersion int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		version := negotiateVersion(r, pathVersion)
+		if version == 0 {
+			http.Error(w, fmt.Sprintf("unsupported API version; supported: 1..%d", latestAPIVersion), http.StatusNotAcceptable)
+			return
+		}
+		var decision ratelimit.Decision
+		caller := callerID(r)
+		if limiter != nil {
+			decision = limiter.Check(caller)
+			if !decision.Allowed {
+				w.Header().Set("Retry-After", fmt.Sprintf("%d", int(decision.RetryAfter.Seconds())+1))
+				http.Error(w, fmt.Sprintf("rate limited (%s)", decision.Standing), http.StatusTooManyRequests)
+				return
+			}
+		}
+		var req detectRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		result, err := det.Detect(req.Text)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		policy := guard.DefaultPolicy()
+		if limiter != nil {
+			limiter.Record(caller, result)
+			if decision.Standing == ratelimit.StandingRestricted {
+				policy = limiter.TightenPolicy(policy)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Guard-API-Version", fmt.Sprintf("%d", version))
+		if version < latestAPIVersion {
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Link", fmt.Sprintf("</v%d/detect>; rel=\"successor-version\"", latestAPIVersion))
+		}
+		switch version {
+		case 1:
+			json.NewEncoder(w).Encode(result)
+		default:
+			json.NewEncoder(w).Encode(detectResponseV2{
+				APIVersion: version,
+				Verdict:    result,
+				Action:     policy.Evaluate(result),
+			})
+		}
+	}
+}
+
+// callerID identifies the caller for rate limiting: the X-Guard-Caller
+// header when the application forwards a user or session ID, otherwise the
+// client address.
+func callerID(r *http.Request) string {
+	if caller := r.Header.Get("X-Guard-Caller"); caller != "" {
+		return caller
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// requireAdmin authenticates admin requests with a constant-time token
+// comparison. With no token configured the wrapped endpoint refuses to
+// serve at all, so the rule API cannot be enabled by accident.
+func requireAdmin(token string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if token == "" {
+			http.Error(w, "admin API disabled: start with -admin-token", http.StatusForbidden)
+			return
+		}
+		got := r.Header.Get("X-Admin-Token")
+		if got == "" {
+			got = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		}
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// handleRules mutates the active rule set. POST takes a guard.PatternChange
+// and applies it only when its dry run passes; the dry-run report is
+// attached to the response either way. DELETE removes a single pattern by
+// threat_type and pattern query parameters.
+func handleRules(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		var change guard.PatternChange
+		if err := json.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode(&change); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		report, err := guard.ApplyChange(change)
+		w.Header().Set("Content-Type", "application/json")
+		status := http.StatusOK
+		applied := err == nil
+		if err != nil {
+			status = http.StatusUnprocessableEntity
+		}
+		w.WriteHeader(status)
+		resp := map[string]any{"applied": applied, "dry_run": report}
+		if err != nil {
+			resp["error"] = err.Error()
+		} else {
+			log.Printf("rule change applied: threat type %q, %d patterns", change.ThreatType, len(change.Patterns))
+		}
+		json.NewEncoder(w).Encode(resp)
+	case http.MethodDelete:
+		threatType := r.URL.Query().Get("threat_type")
+		pattern := r.URL.Query().Get("pattern")
+		if threatType == "" || pattern == "" {
+			http.Error(w, "threat_type and pattern are required", http.StatusBadRequest)
+			return
+		}
+		removed := guard.RemovePattern(threatType, pattern)
+		if removed {
+			log.Printf("rule pattern removed: threat type %q", threatType)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]bool{"removed": removed})
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleDecisions serves the decision ring: ?since=<seq> returns decisions
+// after that sequence number (for tailing), ?limit=<n> caps a plain listing.
+func handleDecisions(ring *detector.DecisionRing) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var records []detector.DecisionRecord
+		if sinceParam := r.URL.Query().Get("since"); sinceParam != "" {
+			since, err := strconv.ParseUint(sinceParam, 10, 64)
+			if err != nil {
+				http.Error(w, "invalid since parameter", http.StatusBadRequest)
+				return
+			}
+			records = ring.Since(since)
+		} else {
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			records = ring.Recent(limit)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(records)
+	}
+}
+
+// newGuardedProxy returns a reverse proxy that scans request bodies before
+// forwarding them to the LLM backend. Blocked requests get a 403 with the
+// ThreatResult as JSON instead of ever reaching the backend.
+func newGuardedProxy(det *detector.EnhancedDetector, queue quarantine.Queue, upstream string) (http.Handler, error) {
+	target, err := url.Parse(upstream)
+	if err != nil {
+		return nil, err
+	}
+	proxy := httputil.NewSingleHostReverseProxy(target)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.Body != nil {
+			body, err := io.ReadAll(r.Body)
+			r.Body.Close()
+			if err != nil {
+				http.Error(w, "failed to read request body", http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			result, err := det.Detect(string(body))
+			if err == nil && result != nil && result.IsThreat {
+				tenant := r.Header.Get("X-Guard-Tenant")
+				action := det.Enforce(tenant, guard.DefaultPolicy(), string(body), result)
+				if action == guard.ActionQuarantine && queue != nil {
+					id, err := queue.Enqueue(r.Context(), quarantine.Item{
+						Tenant: tenant,
+						Input:  string(body),
+						Result: result,
+					})
+					if err != nil {
+						http.Error(w, err.Error(), http.StatusInternalServerError)
+						return
+					}
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusAccepted)
+					json.NewEncoder(w).Encode(map[string]string{
+						"id":     id,
+						"status": string(quarantine.StatusPending),
+					})
+					return
+				}
+				if action == guard.ActionBlock || action == guard.ActionSoftBlock || action == guard.ActionQuarantine {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusForbidden)
+					json.NewEncoder(w).Encode(result)
+					return
+				}
+			}
+		}
+		proxy.ServeHTTP(w, r)
+	}), nil
+}
+
+// registerQuarantineHandlers exposes the review workflow: list pending
+// items, inspect one, and approve or reject it. The whole workflow is
+// admin-only — items carry raw quarantined inputs, and resolving one is
+// the human-review step itself.
+func registerQuarantineHandlers(mux *http.ServeMux, queue quarantine.Queue, adminToken string) {
+	mux.HandleFunc("/v1/quarantine", requireAdmin(adminToken, func(w http.ResponseWriter, r *http.Request) {
+		items, err := queue.Pending(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(items)
+	}))
+	mux.HandleFunc("/v1/quarantine/item", requireAdmin(adminToken, func(w http.ResponseWriter, r *http.Request) {
+		item, err := queue.Get(r.Context(), r.URL.Query().Get("id"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(item)
+	}))
+	mux.HandleFunc("/v1/quarantine/resolve", requireAdmin(adminToken, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var req struct {
+			ID      string `json:"id"`
+			Approve bool   `json:"approve"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		item, err := queue.Resolve(r.Context(), req.ID, req.Approve)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(item)
+	}))
+}
+
+func runHealthcheck(addr string) int {
+	if addr != "" && addr[0] == ':' {
+		addr = "localhost" + addr
+	}
+	client := &http.Client{Timeout: 3 * time.Second}
+	resp, err := client.Get("http://" + addr + "/healthz")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		fmt.Fprintf(os.Stderr, "unhealthy: %s\n", resp.Status)
+		return 1
+	}
+	return 0
+}
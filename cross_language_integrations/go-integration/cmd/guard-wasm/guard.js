@@ -0,0 +1,44 @@
+// Binding for guard.wasm: loads the module and returns the pre-filter API.
+// Requires wasm_exec.js (from $(go env GOROOT)/lib/wasm) to be loaded
+// first, or imported so that globalThis.Go exists.
+//
+// Usage:
+//
+//   import { loadGuard } from "./guard.js";
+//   const guard = await loadGuard("./guard.wasm");
+//   const verdict = guard.scan(userInput);
+//   if (verdict.is_threat) reject(verdict);
+//
+// `source` may be a URL string (browsers, Deno) or a precompiled
+// WebAssembly.Module (Cloudflare Workers, where modules are bound at
+// deploy time and fetch is unavailable for local assets).
+export async function loadGuard(source = "guard.wasm") {
+  if (typeof globalThis.Go !== "function") {
+    throw new Error("wasm_exec.js must be loaded before guard.js");
+  }
+  const go = new globalThis.Go();
+
+  let instance;
+  if (source instanceof WebAssembly.Module) {
+    instance = await WebAssembly.instantiate(source, go.importObject);
+  } else if (typeof WebAssembly.instantiateStreaming === "function") {
+    ({ instance } = await WebAssembly.instantiateStreaming(fetch(source), go.importObject));
+  } else {
+    const bytes = await (await fetch(source)).arrayBuffer();
+    ({ instance } = await WebAssembly.instantiate(bytes, go.importObject));
+  }
+
+  // go.run resolves only when the program exits; the guard runs forever,
+  // so hold the promise without awaiting it.
+  const exited = go.run(instance);
+  exited.catch((err) => console.error("guard.wasm exited:", err));
+
+  const api = globalThis.trustGuard;
+  if (!api) {
+    throw new Error("guard.wasm did not register trustGuard");
+  }
+  return {
+    scan: (text) => api.scan(String(text)),
+    normalize: (text) => api.normalize(String(text)),
+  };
+}
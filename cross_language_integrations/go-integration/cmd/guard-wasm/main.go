@@ -0,0 +1,77 @@
+//go:build js && wasm
+
+// guard-wasm compiles the fast pre-filter stages — normalization, regex,
+// and encoded-payload detection — to WebAssembly so browsers and edge
+// workers can reject obvious attacks before traffic reaches the backend.
+// The model stages stay server-side; a wasm hit is a hard verdict, a miss
+// just means the backend still gets the final say.
+//
+// Build:
+//
+//	GOOS=js GOARCH=wasm go build -o guard.wasm ./cmd/guard-wasm
+//
+// and serve guard.wasm next to guard.js (the binding in this directory)
+// plus wasm_exec.js from $(go env GOROOT)/lib/wasm.
+package main
+
+import (
+	"encoding/json"
+	"syscall/js"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+func main() {
+	api := map[string]any{
+		"scan":      js.FuncOf(scan),
+		"normalize": js.FuncOf(normalize),
+	}
+	js.Global().Set("trustGuard", js.ValueOf(api))
+	// Keep the Go runtime alive; calls arrive through the exported funcs.
+	select {}
+}
+
+// scan runs the regex and encoded-payload stages and returns the verdict
+// as a plain JS object (the same shape the REST API serves).
+func scan(this js.Value, args []js.Value) any {
+	if len(args) != 1 {
+		return errorValue("scan expects exactly one string argument")
+	}
+	text := args[0].String()
+	result := guard.CheckRegex(text)
+	if result == nil {
+		result = guard.CheckEncoded(text)
+	}
+	if result == nil {
+		result = &guard.ThreatResult{
+			IsThreat:   false,
+			ThreatType: "benign",
+			Confidence: 0.5,
+			Reasoning:  "No regex or encoding patterns matched (pre-filter only)",
+		}
+	}
+	return toJS(result)
+}
+
+// normalize exposes the evasion-resistant normalization on its own, for
+// callers that want the folded text and detected techniques.
+func normalize(this js.Value, args []js.Value) any {
+	if len(args) != 1 {
+		return errorValue("normalize expects exactly one string argument")
+	}
+	return toJS(guard.Normalize(args[0].String()))
+}
+
+// toJS converts a Go value to a JS object via its JSON form, so field
+// names match the wire format everywhere else.
+func toJS(v any) any {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return errorValue("encoding result failed: " + err.Error())
+	}
+	return js.Global().Get("JSON").Call("parse", string(payload))
+}
+
+func errorValue(message string) any {
+	return js.ValueOf(map[string]any{"error": message})
+}
@@ -0,0 +1,15 @@
+//go:build !(js && wasm)
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// Native builds get a pointer to the real target instead of a binary that
+// cannot work; this also keeps `go build ./...` green on every platform.
+func main() {
+	fmt.Fprintln(os.Stderr, "guard-wasm targets WebAssembly; build with GOOS=js GOARCH=wasm")
+	os.Exit(2)
+}
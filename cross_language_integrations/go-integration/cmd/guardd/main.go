@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/marcusjohansson/trust-go/pkg/detector"
+	"github.com/marcusjohansson/trust-go/pkg/extauthz"
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+	"github.com/marcusjohansson/trust-go/pkg/guardmetrics"
+)
+
+// guardd is the sidecar deployment of the hybrid detector: a small REST
+// server that non-Go services call over localhost.
+
+type detectRequest struct {
+	Text string `json:"text"`
+}
+
+type batchRequest struct {
+	Texts []string `json:"texts"`
+}
+
+type batchResponse struct {
+	Results []*guard.ThreatResult `json:"results"`
+}
+
+func main() {
+	addr := flag.String("addr", ":8080", "listen address")
+	configPath := flag.String("config", "./guard-config-enhanced.json", "path to guard config")
+	regexPath := flag.String("patterns", "./regex_patterns.json", "path to regex patterns")
+	modelEndpoint := flag.String("model", "http://localhost:8000", "model API endpoint")
+	cacheTTL := flag.Duration("cache-ttl", 0, "result cache TTL (0 disables caching)")
+	cacheSize := flag.Int("cache-size", 10000, "result cache max entries")
+	extAuthzAddr := flag.String("ext-authz", "", "listen address for the Envoy ext_authz gRPC service (empty disables it)")
+	flag.Parse()
+
+	det, err := detector.NewEnhanced(*configPath, *regexPath, *modelEndpoint)
+	if err != nil {
+		log.Fatalf("failed to initialize detector: %v", err)
+	}
+	if *cacheTTL > 0 {
+		det.EnableCache(detector.CacheConfig{TTL: *cacheTTL, MaxEntries: *cacheSize})
+	}
+
+	var ready atomic.Bool
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if !ready.Load() {
+			http.Error(w, "shutting down", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, "ready")
+	})
+	mux.HandleFunc("/v1/detect", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var req detectRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		result, err := det.DetectContext(r.Context(), req.Text)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, result)
+	})
+	mux.HandleFunc("/v1/detect/batch", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var req batchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		resp := batchResponse{Results: make([]*guard.ThreatResult, len(req.Texts))}
+		for i, text := range req.Texts {
+			result, err := det.DetectContext(r.Context(), text)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			resp.Results[i] = result
+		}
+		writeJSON(w, resp)
+	})
+	mux.Handle("/metrics", guardmetrics.Handler())
+
+	server := &http.Server{
+		Addr:    *addr,
+		Handler: mux,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var grpcServer *grpc.Server
+	if *extAuthzAddr != "" {
+		listener, err := net.Listen("tcp", *extAuthzAddr)
+		if err != nil {
+			log.Fatalf("ext_authz listen failed: %v", err)
+		}
+		grpcServer = grpc.NewServer()
+		extauthz.NewServer(det, nil).Register(grpcServer)
+		go func() {
+			log.Printf("ext_authz listening on %s", *extAuthzAddr)
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Fatalf("ext_authz server error: %v", err)
+			}
+		}()
+	}
+
+	go func() {
+		ready.Store(true)
+		log.Printf("guardd listening on %s", *addr)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("server error: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	// Flip readiness first so load balancers drain us, then let in-flight
+	// requests finish.
+	ready.Store(false)
+	log.Println("shutting down...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("graceful shutdown failed: %v", err)
+	}
+	if grpcServer != nil {
+		grpcServer.GracefulStop()
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
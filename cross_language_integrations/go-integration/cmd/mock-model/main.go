@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// mock-model stands in for the Python model API during local development and
+// container deployments. It serves the same POST /detect contract using only
+// the regex baseline, so the full pipeline can run without the 86M model.
+
+type detectRequest struct {
+	Text string `json:"text"`
+}
+
+func main() {
+	addr := flag.String("addr", ":8000", "listen address")
+	healthcheck := flag.Bool("healthcheck", false, "probe the local server and exit (used by container HEALTHCHECK)")
+	flag.Parse()
+
+	if *healthcheck {
+		os.Exit(runHealthcheck(*addr))
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("/detect", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var req detectRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		result := guard.CheckRegex(req.Text)
+		if result == nil {
+			result = &guard.ThreatResult{
+				IsThreat:   false,
+				ThreatType: "benign",
+				Confidence: 0.0,
+				Reasoning:  "No threat patterns matched (mock model)",
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	})
+
+	log.Printf("mock-model listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
+
+func runHealthcheck(addr string) int {
+	if addr != "" && addr[0] == ':' {
+		addr = "localhost" + addr
+	}
+	client := &http.Client{Timeout: 3 * time.Second}
+	resp, err := client.Get("http://" + addr + "/healthz")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		fmt.Fprintf(os.Stderr, "unhealthy: %s\n", resp.Status)
+		return 1
+	}
+	return 0
+}
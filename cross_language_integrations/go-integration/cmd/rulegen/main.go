@@ -0,0 +1,56 @@
+// rulegen writes the canonical rule bundle out as artifacts for the other
+// language integrations:
+//
+//	rulegen -js ../ts-integration/src/rules.generated.js -py ../python/rules_generated.py
+//
+// Unsupported-construct warnings go to stderr; the exit code is non-zero
+// when any pattern had to be omitted.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+	"github.com/marcusjohansson/trust-go/pkg/rulegen"
+)
+
+func main() {
+	jsPath := flag.String("js", "", "write a JS RegExp module to this path")
+	pyPath := flag.String("py", "", "write a Python re module to this path")
+	flag.Parse()
+
+	if *jsPath == "" && *pyPath == "" {
+		fmt.Fprintln(os.Stderr, "rulegen: nothing to do; pass -js and/or -py")
+		os.Exit(2)
+	}
+
+	var warned bool
+	if *jsPath != "" {
+		artifact, warnings := rulegen.CompileJS(guard.RegexPatterns)
+		warned = report(warnings) || warned
+		if err := os.WriteFile(*jsPath, []byte(artifact), 0o644); err != nil {
+			fmt.Fprintf(os.Stderr, "rulegen: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if *pyPath != "" {
+		artifact, warnings := rulegen.CompilePython(guard.RegexPatterns)
+		warned = report(warnings) || warned
+		if err := os.WriteFile(*pyPath, []byte(artifact), 0o644); err != nil {
+			fmt.Fprintf(os.Stderr, "rulegen: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if warned {
+		os.Exit(1)
+	}
+}
+
+func report(warnings []rulegen.Warning) bool {
+	for _, warning := range warnings {
+		fmt.Fprintln(os.Stderr, "rulegen: "+warning.String())
+	}
+	return len(warnings) > 0
+}
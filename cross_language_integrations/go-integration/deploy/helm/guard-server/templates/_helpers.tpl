@@ -0,0 +1,22 @@
+{{- define "guard-server.fullname" -}}
+{{- printf "%s-%s" .Release.Name .Chart.Name | trunc 63 | trimSuffix "-" -}}
+{{- end -}}
+
+{{- define "guard-server.labels" -}}
+app.kubernetes.io/name: {{ .Chart.Name }}
+app.kubernetes.io/instance: {{ .Release.Name }}
+app.kubernetes.io/managed-by: {{ .Release.Service }}
+{{- end -}}
+
+{{- define "guard-server.selectorLabels" -}}
+app.kubernetes.io/name: {{ .Chart.Name }}
+app.kubernetes.io/instance: {{ .Release.Name }}
+{{- end -}}
+
+{{- define "guard-server.rulesConfigMap" -}}
+{{- if .Values.rules.existingConfigMap -}}
+{{- .Values.rules.existingConfigMap -}}
+{{- else -}}
+{{- include "guard-server.fullname" . }}-rules
+{{- end -}}
+{{- end -}}
@@ -0,0 +1,153 @@
+// Package appeal implements the user-facing appeal workflow for blocked
+// requests: every block decision gets a reference code the user can cite,
+// reviewers see the full decision trace in a queue, and resolutions feed
+// the feedback loop so repeated false positives get fixed instead of
+// re-litigated.
+package appeal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Status of an appeal in the review queue.
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusApproved Status = "approved" // block judged wrong, decision overturned
+	StatusDenied   Status = "denied"   // block upheld
+)
+
+// DecisionRecord is the trace stored for a block decision so reviewers see
+// exactly what happened.
+type DecisionRecord struct {
+	Code      string              `json:"code"`
+	Input     string              `json:"input"`
+	Result    *guard.ThreatResult `json:"result"`
+	Action    guard.Action        `json:"action"`
+	DecidedAt time.Time           `json:"decided_at"`
+}
+
+// Appeal is a user's request to review a block decision.
+type Appeal struct {
+	Code        string         `json:"code"`
+	UserMessage string         `json:"user_message"`
+	SubmittedAt time.Time      `json:"submitted_at"`
+	Status      Status         `json:"status"`
+	Resolution  string         `json:"resolution,omitempty"`
+	ResolvedAt  time.Time      `json:"resolved_at,omitempty"`
+	Decision    DecisionRecord `json:"decision"`
+}
+
+// Queue holds block decisions and their appeals.
+type Queue struct {
+	mu        sync.Mutex
+	decisions map[string]DecisionRecord
+	appeals   map[string]*Appeal
+
+	// OnResolution, when set, is called for every resolved appeal so the
+	// outcome can feed the feedback loop (e.g. false-positive suppression).
+	OnResolution func(appeal Appeal)
+}
+
+// NewQueue creates an empty appeal queue.
+func NewQueue() *Queue {
+	return &Queue{
+		decisions: map[string]DecisionRecord{},
+		appeals:   map[string]*Appeal{},
+	}
+}
+
+// RecordDecision stores a block decision and returns the reference code to
+// surface to the user ("blocked — reference AP-xxxxxxxx").
+func (q *Queue) RecordDecision(input string, result *guard.ThreatResult, action guard.Action) string {
+	now := time.Now()
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d", input, now.UnixNano())))
+	code := "AP-" + hex.EncodeToString(sum[:4])
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.decisions[code] = DecisionRecord{
+		Code:      code,
+		Input:     input,
+		Result:    result,
+		Action:    action,
+		DecidedAt: now,
+	}
+	return code
+}
+
+// Submit files an appeal for a reference code.
+func (q *Queue) Submit(code, userMessage string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	decision, ok := q.decisions[code]
+	if !ok {
+		return fmt.Errorf("unknown reference code %q", code)
+	}
+	if _, exists := q.appeals[code]; exists {
+		return fmt.Errorf("appeal for %q already filed", code)
+	}
+	q.appeals[code] = &Appeal{
+		Code:        code,
+		UserMessage: userMessage,
+		SubmittedAt: time.Now(),
+		Status:      StatusPending,
+		Decision:    decision,
+	}
+	return nil
+}
+
+// Pending returns open appeals, oldest first, with their full traces.
+func (q *Queue) Pending() []Appeal {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var pending []Appeal
+	for _, appeal := range q.appeals {
+		if appeal.Status == StatusPending {
+			pending = append(pending, *appeal)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].SubmittedAt.Before(pending[j].SubmittedAt)
+	})
+	return pending
+}
+
+// Resolve closes an appeal and notifies the feedback hook.
+func (q *Queue) Resolve(code string, approved bool, resolution string) error {
+	q.mu.Lock()
+	appeal, ok := q.appeals[code]
+	if !ok {
+		q.mu.Unlock()
+		return fmt.Errorf("no appeal for reference code %q", code)
+	}
+	if appeal.Status != StatusPending {
+		q.mu.Unlock()
+		return fmt.Errorf("appeal %q already resolved", code)
+	}
+	if approved {
+		appeal.Status = StatusApproved
+	} else {
+		appeal.Status = StatusDenied
+	}
+	appeal.Resolution = resolution
+	appeal.ResolvedAt = time.Now()
+	resolved := *appeal
+	callback := q.OnResolution
+	q.mu.Unlock()
+
+	if callback != nil {
+		callback(resolved)
+	}
+	return nil
+}
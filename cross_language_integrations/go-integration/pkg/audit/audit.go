@@ -0,0 +1,93 @@
+// Package audit provides the compliance audit trail: an append-only record
+// of every detection decision. Inputs are stored as SHA-256 hashes by
+// default; full-input capture is opt-in because audit logs outlive the
+// retention promises made for the inputs themselves.
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Record is one detection decision in the audit trail.
+type Record struct {
+	Timestamp time.Time `json:"timestamp"`
+	// InputHash is the SHA-256 of the raw input, so records can be joined
+	// against stored traffic without the log itself retaining inputs.
+	InputHash string `json:"input_hash"`
+	// Input is only populated when full-input capture is enabled.
+	Input     string              `json:"input,omitempty"`
+	Verdict   *guard.ThreatResult `json:"verdict"`
+	Stage     string              `json:"stage"`
+	LatencyMS float64             `json:"latency_ms"`
+}
+
+// HashInput returns the InputHash value for an input: a salted hash when a
+// salt rotator is installed (see UseSaltRotator), a plain SHA-256 otherwise.
+func HashInput(input string) string {
+	rotatorMu.RLock()
+	r := rotator
+	rotatorMu.RUnlock()
+	if r != nil {
+		return r.Hash(input)
+	}
+	sum := sha256.Sum256([]byte(input))
+	return hex.EncodeToString(sum[:])
+}
+
+// Logger receives every detection decision. Implementations must be safe
+// for concurrent use.
+type Logger interface {
+	Log(record Record) error
+}
+
+// WriterLogger writes records as JSON lines to any io.Writer.
+type WriterLogger struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterLogger returns a Logger emitting JSONL to w.
+func NewWriterLogger(w io.Writer) *WriterLogger {
+	return &WriterLogger{w: w}
+}
+
+// Log writes one record as a JSON line.
+func (l *WriterLogger) Log(record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err = l.w.Write(append(line, '\n'))
+	return err
+}
+
+// FileLogger appends JSONL records to a file.
+type FileLogger struct {
+	*WriterLogger
+	file *os.File
+}
+
+// NewFileLogger opens (or creates) the JSONL audit file at path in
+// append-only mode.
+func NewFileLogger(path string) (*FileLogger, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileLogger{WriterLogger: NewWriterLogger(file), file: file}, nil
+}
+
+// Close closes the underlying file.
+func (l *FileLogger) Close() error {
+	return l.file.Close()
+}
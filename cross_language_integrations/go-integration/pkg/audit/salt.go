@@ -0,0 +1,113 @@
+package audit
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// SaltRotator manages the salt used for input hashing. A static salt makes
+// long-term stored hashes increasingly re-identifiable, so the salt rotates
+// on a fixed period; a configurable number of previous salts is kept so
+// hashes from the overlap window remain linkable to fresh inputs.
+type SaltRotator struct {
+	period  time.Duration
+	overlap int
+
+	mu      sync.Mutex
+	salts   [][]byte // newest first
+	rotated time.Time
+}
+
+// NewSaltRotator creates a rotator generating a fresh salt every period
+// (0 uses 24h), keeping overlap previous salts linkable (0 uses 1).
+func NewSaltRotator(period time.Duration, overlap int) *SaltRotator {
+	if period <= 0 {
+		period = 24 * time.Hour
+	}
+	if overlap <= 0 {
+		overlap = 1
+	}
+	r := &SaltRotator{period: period, overlap: overlap}
+	r.salts = [][]byte{newSalt()}
+	r.rotated = time.Now()
+	return r
+}
+
+// Hash returns the salted hash of input under the current salt.
+func (r *SaltRotator) Hash(input string) string {
+	return hashWithSalt(input, r.activeSalts()[0])
+}
+
+// Hashes returns the hashes of input under the current salt and every salt
+// still inside the overlap window, newest first. Stored hashes from the
+// window can be joined against any of them.
+func (r *SaltRotator) Hashes(input string) []string {
+	salts := r.activeSalts()
+	hashes := make([]string, len(salts))
+	for i, salt := range salts {
+		hashes[i] = hashWithSalt(input, salt)
+	}
+	return hashes
+}
+
+// Matches reports whether hash was produced from input under the current
+// salt or any salt still in the overlap window.
+func (r *SaltRotator) Matches(input, hash string) bool {
+	for _, candidate := range r.Hashes(input) {
+		if candidate == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// activeSalts rotates if the period has elapsed and returns the current
+// plus overlapping salts, newest first.
+func (r *SaltRotator) activeSalts() [][]byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for now.Sub(r.rotated) >= r.period {
+		r.salts = append([][]byte{newSalt()}, r.salts...)
+		if len(r.salts) > r.overlap+1 {
+			r.salts = r.salts[:r.overlap+1]
+		}
+		r.rotated = r.rotated.Add(r.period)
+		// A long idle gap should not spin through every missed rotation.
+		if now.Sub(r.rotated) >= r.period {
+			r.rotated = now
+		}
+	}
+	return r.salts
+}
+
+func newSalt() []byte {
+	salt := make([]byte, 32)
+	rand.Read(salt)
+	return salt
+}
+
+func hashWithSalt(input string, salt []byte) string {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte(input))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// rotator, when set, replaces the unsalted default in HashInput.
+var (
+	rotatorMu sync.RWMutex
+	rotator   *SaltRotator
+)
+
+// UseSaltRotator routes HashInput through r, so every input hash in audit
+// records and published events is salted and rotates with it. Pass nil to
+// restore unsalted hashing.
+func UseSaltRotator(r *SaltRotator) {
+	rotatorMu.Lock()
+	defer rotatorMu.Unlock()
+	rotator = r
+}
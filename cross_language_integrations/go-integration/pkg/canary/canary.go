@@ -0,0 +1,85 @@
+// Package canary generates unique marker strings to embed in system prompts
+// and detects them in model output or downstream logs. A marker showing up
+// anywhere outside the prompt proves exfiltration. Tokens are HMAC-derived,
+// so any holder of the signing secret can validate a token without a
+// central token store.
+package canary
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// tokenPattern matches embedded canary tokens: CNRY-<context>-<mac>.
+var tokenPattern = regexp.MustCompile(`CNRY-([A-Za-z0-9_.:-]+)-([0-9a-f]{16})`)
+
+// Generator mints and validates canary tokens for one signing secret.
+type Generator struct {
+	secret []byte
+}
+
+// NewGenerator creates a generator. The secret must be shared by every
+// component that needs to validate tokens.
+func NewGenerator(secret []byte) *Generator {
+	return &Generator{secret: secret}
+}
+
+// Token derives the canary marker for a context identifier (e.g. a prompt
+// registry name plus version). The same (secret, contextID) pair always
+// yields the same token.
+func (g *Generator) Token(contextID string) string {
+	return fmt.Sprintf("CNRY-%s-%s", contextID, g.mac(contextID))
+}
+
+func (g *Generator) mac(contextID string) string {
+	h := hmac.New(sha256.New, g.secret)
+	h.Write([]byte(contextID))
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// Match is one canary token found in scanned text.
+type Match struct {
+	Token     string `json:"token"`
+	ContextID string `json:"context_id"`
+	// Valid is true when the token's MAC verifies against our secret;
+	// invalid matches are likely guesses or tokens from another secret.
+	Valid bool `json:"valid"`
+}
+
+// Scan finds all canary tokens in text and validates each against the
+// generator's secret.
+func (g *Generator) Scan(text string) []Match {
+	var matches []Match
+	for _, m := range tokenPattern.FindAllStringSubmatch(text, -1) {
+		matches = append(matches, Match{
+			Token:     m[0],
+			ContextID: m[1],
+			Valid:     hmac.Equal([]byte(m[2]), []byte(g.mac(m[1]))),
+		})
+	}
+	return matches
+}
+
+// Check returns a critical ThreatResult when text contains a valid canary
+// token, proving the system prompt leaked into the scanned channel. It
+// returns nil when no valid token is present.
+func (g *Generator) Check(text string) *guard.ThreatResult {
+	for _, match := range g.Scan(text) {
+		if !match.Valid {
+			continue
+		}
+		return &guard.ThreatResult{
+			IsThreat:   true,
+			ThreatType: "system_prompt_attack",
+			Confidence: 1.0,
+			Reasoning:  fmt.Sprintf("Canary token %q for context %q found in output", match.Token, match.ContextID),
+			Severity:   guard.SeverityCritical,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+// Package client is a typed Go client for guard-server. It implements the
+// same detection surface as the in-process detector, so switching between
+// embedded and remote detection is a one-line change:
+//
+//	det, _ := detector.NewEnhanced(cfg, patterns, model) // embedded
+//	det := client.New("http://guard:8080")               // remote
+//
+// The client retries transient failures with backoff, applies a request
+// timeout, and opens a circuit breaker after repeated failures so a dead
+// guard-server does not stall the application's request path.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Client calls a remote guard-server. It is safe for concurrent use.
+type Client struct {
+	baseURL string
+	http    *http.Client
+	caller  string
+	retries int
+	backoff time.Duration
+
+	breakerThreshold int
+	breakerCooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+// Option configures the client.
+type Option func(*Client)
+
+// WithTimeout sets the per-request timeout (default 5s).
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) { c.http.Timeout = timeout }
+}
+
+// WithHTTPClient replaces the underlying HTTP client entirely.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.http = httpClient }
+}
+
+// WithRetries sets how many times a failed call is retried (default 2).
+func WithRetries(retries int) Option {
+	return func(c *Client) { c.retries = retries }
+}
+
+// WithCaller forwards a caller ID on every request for the server's
+// per-caller rate limiting (the X-Guard-Caller header).
+func WithCaller(caller string) Option {
+	return func(c *Client) { c.caller = caller }
+}
+
+// WithBreaker tunes the circuit breaker: after threshold consecutive
+// failures, calls fail fast for cooldown (defaults: 5 failures, 30s).
+func WithBreaker(threshold int, cooldown time.Duration) Option {
+	return func(c *Client) {
+		c.breakerThreshold = threshold
+		c.breakerCooldown = cooldown
+	}
+}
+
+// New creates a client for the guard-server at baseURL.
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:          baseURL,
+		http:             &http.Client{Timeout: 5 * time.Second},
+		retries:          2,
+		backoff:          200 * time.Millisecond,
+		breakerThreshold: 5,
+		breakerCooldown:  30 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// ErrCircuitOpen is returned while the breaker is open; callers should
+// treat it like any other guard outage (fail open or closed per policy).
+var ErrCircuitOpen = fmt.Errorf("guard-server circuit breaker open")
+
+// Detect implements the in-process detector's signature.
+func (c *Client) Detect(text string) (*guard.ThreatResult, error) {
+	return c.DetectContext(context.Background(), text)
+}
+
+// DetectContext performs remote detection honoring the given context.
+func (c *Client) DetectContext(ctx context.Context, text string) (*guard.ThreatResult, error) {
+	if !c.allow() {
+		return nil, ErrCircuitOpen
+	}
+	var lastErr error
+	for attempt := 0; attempt <= c.retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(c.backoff << (attempt - 1)):
+			}
+		}
+		result, retryable, err := c.call(ctx, text)
+		if err == nil {
+			c.recordSuccess()
+			return result, nil
+		}
+		lastErr = err
+		if !retryable {
+			break
+		}
+	}
+	c.recordFailure()
+	return nil, lastErr
+}
+
+// call performs one request; retryable reports whether the failure is worth
+// another attempt (network errors and 5xx, not 4xx).
+func (c *Client) call(ctx context.Context, text string) (result *guard.ThreatResult, retryable bool, err error) {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return nil, false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		c.baseURL+"/v1/detect", bytes.NewReader(body))
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.caller != "" {
+		req.Header.Set("X-Guard-Caller", c.caller)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, true, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode >= 500,
+			fmt.Errorf("guard-server returned status: %s", resp.Status)
+	}
+	var decoded guard.ThreatResult
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return nil, false, err
+	}
+	return &decoded, false, nil
+}
+
+// allow reports whether a call may proceed under the breaker. An expired
+// cooldown lets one probe through (half-open).
+func (c *Client) allow() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.openUntil.IsZero() {
+		return true
+	}
+	if time.Now().Before(c.openUntil) {
+		return false
+	}
+	// Half-open: allow the probe, re-arm the cooldown so concurrent calls
+	// keep failing fast until the probe succeeds.
+	c.openUntil = time.Now().Add(c.breakerCooldown)
+	return true
+}
+
+func (c *Client) recordSuccess() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failures = 0
+	c.openUntil = time.Time{}
+}
+
+func (c *Client) recordFailure() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failures++
+	if c.failures >= c.breakerThreshold {
+		c.openUntil = time.Now().Add(c.breakerCooldown)
+	}
+}
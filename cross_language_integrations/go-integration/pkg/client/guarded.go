@@ -0,0 +1,204 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/detector"
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Message is a chat message in the OpenAI wire format.
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Finding records one guard decision made while handling a request.
+type Finding struct {
+	// Stage is "input" for outgoing messages and "output" for the model reply.
+	Stage string `json:"stage"`
+	// MessageIndex is the index of the scanned input message (-1 for output).
+	MessageIndex int                 `json:"message_index"`
+	Result       *guard.ThreatResult `json:"result"`
+	Action       guard.Action        `json:"action"`
+}
+
+// Response is a chat completion with the guard findings attached.
+type Response struct {
+	// Content is the model reply, empty when the request was blocked.
+	Content string `json:"content"`
+	// Blocked reports whether the guard stopped the request or response.
+	Blocked  bool      `json:"blocked"`
+	Findings []Finding `json:"findings,omitempty"`
+}
+
+// GuardedClient wraps an OpenAI-compatible chat API with the threat
+// detection pipeline: outgoing messages are scanned (and optionally
+// sanitized), the model is called, and the reply is scanned before it is
+// returned. It is the simplest way to add guarding to an application.
+type GuardedClient struct {
+	BaseURL string // e.g. https://api.openai.com/v1
+	APIKey  string
+	Model   string
+
+	// SanitizeInputs strips matched threat patterns from flagged (not
+	// blocked) messages instead of forwarding them verbatim.
+	SanitizeInputs bool
+
+	detector *detector.EnhancedDetector
+	policy   guard.Policy
+	client   *http.Client
+}
+
+// NewGuardedClient creates a guarded wrapper around an OpenAI-compatible API
+// using the default policy.
+func NewGuardedClient(baseURL, apiKey, model string, det *detector.EnhancedDetector) *GuardedClient {
+	return &GuardedClient{
+		BaseURL:  baseURL,
+		APIKey:   apiKey,
+		Model:    model,
+		detector: det,
+		policy:   guard.DefaultPolicy(),
+		client: &http.Client{
+			Timeout: 60 * time.Second,
+		},
+	}
+}
+
+// SetPolicy replaces the policy used for block/flag/allow decisions.
+func (c *GuardedClient) SetPolicy(policy guard.Policy) {
+	c.policy = policy
+}
+
+// Chat scans messages, calls the model, scans the reply, and returns the
+// result with all guard findings attached. A blocked request or response
+// returns Blocked=true with empty Content and a nil error.
+func (c *GuardedClient) Chat(ctx context.Context, messages []Message) (*Response, error) {
+	resp := &Response{}
+
+	// Stage 1: scan outgoing messages.
+	outgoing := make([]Message, len(messages))
+	copy(outgoing, messages)
+	for i, msg := range outgoing {
+		if msg.Role != "user" {
+			continue
+		}
+		result, err := c.detector.DetectContext(ctx, msg.Content)
+		if err != nil {
+			return nil, fmt.Errorf("input scan failed: %w", err)
+		}
+		action := c.policy.Evaluate(result)
+		if result != nil && result.IsThreat {
+			resp.Findings = append(resp.Findings, Finding{
+				Stage:        "input",
+				MessageIndex: i,
+				Result:       result,
+				Action:       action,
+			})
+		}
+		switch action {
+		case guard.ActionBlock:
+			resp.Blocked = true
+			return resp, nil
+		case guard.ActionFlag:
+			if c.SanitizeInputs {
+				outgoing[i].Content = sanitize(msg.Content)
+			}
+		}
+	}
+
+	// Stage 2: call the model.
+	content, err := c.complete(ctx, outgoing)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stage 3: scan the reply before handing it to the application.
+	result, err := c.detector.DetectContext(ctx, content)
+	if err != nil {
+		return nil, fmt.Errorf("output scan failed: %w", err)
+	}
+	action := c.policy.Evaluate(result)
+	if result != nil && result.IsThreat {
+		resp.Findings = append(resp.Findings, Finding{
+			Stage:        "output",
+			MessageIndex: -1,
+			Result:       result,
+			Action:       action,
+		})
+	}
+	if action == guard.ActionBlock {
+		resp.Blocked = true
+		return resp, nil
+	}
+
+	resp.Content = content
+	return resp, nil
+}
+
+type chatRequest struct {
+	Model    string    `json:"model"`
+	Messages []Message `json:"messages"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message Message `json:"message"`
+	} `json:"choices"`
+}
+
+func (c *GuardedClient) complete(ctx context.Context, messages []Message) (string, error) {
+	reqBody, err := json.Marshal(chatRequest{Model: c.Model, Messages: messages})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+"/chat/completions", bytes.NewBuffer(reqBody))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	}
+
+	httpResp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("chat API returned status: %s", httpResp.Status)
+	}
+
+	var apiResp chatResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&apiResp); err != nil {
+		return "", err
+	}
+	if len(apiResp.Choices) == 0 {
+		return "", fmt.Errorf("chat API returned no choices")
+	}
+	return apiResp.Choices[0].Message.Content, nil
+}
+
+// sanitize removes every matching threat pattern from the text. It is a
+// best-effort cleanup for flagged-but-allowed messages.
+func sanitize(text string) string {
+	for _, patterns := range guard.SnapshotPatterns() {
+		for _, pattern := range patterns {
+			re, err := regexp.Compile("(?i)" + pattern)
+			if err != nil {
+				continue
+			}
+			text = re.ReplaceAllString(text, "[removed]")
+		}
+	}
+	return text
+}
@@ -0,0 +1,35 @@
+// Package codescan analyzes suspected SQL and shell payloads with real
+// lexers instead of flat regexes. The code_injection patterns fire on any
+// input that mentions SELECT or a pipe character; the lexers only report
+// when the tokens actually form an injection structure, which raises
+// precision enough to treat a finding as near-certain.
+package codescan
+
+import (
+	"strings"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Scan runs both lexers over the input and returns a high-precision
+// code_injection verdict, or nil when neither lexer finds an injection
+// structure.
+func Scan(text string) *guard.ThreatResult {
+	var findings []string
+	for _, finding := range AnalyzeSQL(text) {
+		findings = append(findings, "sql:"+finding)
+	}
+	for _, finding := range AnalyzeShell(text) {
+		findings = append(findings, "shell:"+finding)
+	}
+	if len(findings) == 0 {
+		return nil
+	}
+	return &guard.ThreatResult{
+		IsThreat:   true,
+		ThreatType: "code_injection",
+		Confidence: 0.97,
+		Reasoning:  "Lexical analysis found injection structure: " + strings.Join(findings, ", "),
+		Severity:   guard.SeverityForType("code_injection"),
+	}
+}
@@ -0,0 +1,125 @@
+package codescan
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Shell lexing, mirroring the SQL side: tokenize a suspected command line
+// and look for the structures attackers need — command substitution, pipes
+// into a network client or another shell, destructive rm invocations, and
+// /dev/tcp redirects — rather than pattern-matching on substrings.
+
+type shellTokenKind int
+
+const (
+	shellWord shellTokenKind = iota
+	shellOp
+	shellSubstStart // $( or backtick
+)
+
+type shellToken struct {
+	kind shellTokenKind
+	text string
+}
+
+// lexShell tokenizes input. Quoted strings become single word tokens with
+// the quotes removed; multi-character operators (&&, ||, 2>) collapse to
+// their operator class.
+func lexShell(input string) []shellToken {
+	var tokens []shellToken
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '\'' || r == '"':
+			quote := r
+			i++
+			start := i
+			for i < len(runes) && runes[i] != quote {
+				i++
+			}
+			tokens = append(tokens, shellToken{shellWord, string(runes[start:min(i, len(runes))])})
+			i++
+		case r == '$' && i+1 < len(runes) && runes[i+1] == '(':
+			tokens = append(tokens, shellToken{shellSubstStart, "$("})
+			i += 2
+		case r == '`':
+			tokens = append(tokens, shellToken{shellSubstStart, "`"})
+			i++
+		case strings.ContainsRune("|;&<>", r):
+			start := i
+			for i < len(runes) && strings.ContainsRune("|;&<>", runes[i]) {
+				i++
+			}
+			tokens = append(tokens, shellToken{shellOp, string(runes[start:i])})
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) &&
+				!strings.ContainsRune("|;&<>'\"`", runes[i]) {
+				i++
+			}
+			tokens = append(tokens, shellToken{shellWord, string(runes[start:i])})
+		}
+	}
+	return tokens
+}
+
+// networkClients are programs a pipe into which means data leaves the host
+// or remote code comes in.
+var networkClients = map[string]bool{
+	"curl": true, "wget": true, "nc": true, "ncat": true,
+	"bash": true, "sh": true, "zsh": true, "python": true,
+}
+
+// AnalyzeShell lexes input and returns the command-injection structures
+// found: command_substitution, pipe_to_network, destructive_rm,
+// dev_tcp_redirect.
+func AnalyzeShell(input string) []string {
+	tokens := lexShell(input)
+	var findings []string
+	add := func(finding string) {
+		for _, existing := range findings {
+			if existing == finding {
+				return
+			}
+		}
+		findings = append(findings, finding)
+	}
+
+	for i, token := range tokens {
+		switch {
+		case token.kind == shellSubstStart:
+			add("command_substitution")
+		case token.kind == shellOp && strings.HasPrefix(token.text, "|"):
+			if i+1 < len(tokens) && tokens[i+1].kind == shellWord &&
+				networkClients[baseName(tokens[i+1].text)] {
+				add("pipe_to_network")
+			}
+		case token.kind == shellOp && strings.Contains(token.text, ">"):
+			if i+1 < len(tokens) && strings.HasPrefix(tokens[i+1].text, "/dev/tcp/") {
+				add("dev_tcp_redirect")
+			}
+		case token.kind == shellWord && baseName(token.text) == "rm":
+			for j := i + 1; j < len(tokens) && tokens[j].kind == shellWord; j++ {
+				flags := tokens[j].text
+				if strings.HasPrefix(flags, "-") &&
+					strings.ContainsRune(flags, 'r') && strings.ContainsRune(flags, 'f') {
+					add("destructive_rm")
+					break
+				}
+			}
+		}
+	}
+	return findings
+}
+
+// baseName strips a path prefix so /usr/bin/curl matches curl.
+func baseName(word string) string {
+	if idx := strings.LastIndexByte(word, '/'); idx >= 0 {
+		return word[idx+1:]
+	}
+	return word
+}
@@ -0,0 +1,165 @@
+package codescan
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SQL lexing. The flat regexes flag anything that smells of SQL; the lexer
+// instead tokenizes the input and looks for structures that only appear in
+// injections: UNION SELECT chains, stacked queries, and tautological OR
+// comparisons. Matching on token structure survives the whitespace and
+// comment tricks that defeat patterns ("UNION/**/SELECT").
+
+type sqlTokenKind int
+
+const (
+	sqlWord sqlTokenKind = iota
+	sqlString
+	sqlNumber
+	sqlOp
+	sqlSemicolon
+)
+
+type sqlToken struct {
+	kind sqlTokenKind
+	text string
+}
+
+// lexSQL tokenizes input, dropping comments and whitespace. Words are
+// uppercased so keyword checks are case-insensitive.
+func lexSQL(input string) []sqlToken {
+	var tokens []sqlToken
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			for i+1 < len(runes) && !(runes[i] == '*' && runes[i+1] == '/') {
+				i++
+			}
+			i += 2
+		case r == '\'' || r == '"':
+			quote := r
+			start := i
+			i++
+			for i < len(runes) && runes[i] != quote {
+				i++
+			}
+			i++
+			if i > len(runes) {
+				i = len(runes)
+			}
+			tokens = append(tokens, sqlToken{sqlString, string(runes[start:min(i, len(runes))])})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, sqlToken{sqlWord, strings.ToUpper(string(runes[start:i]))})
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, sqlToken{sqlNumber, string(runes[start:i])})
+		case r == ';':
+			tokens = append(tokens, sqlToken{sqlSemicolon, ";"})
+			i++
+		default:
+			tokens = append(tokens, sqlToken{sqlOp, string(r)})
+			i++
+		}
+	}
+	return tokens
+}
+
+// sqlStatementKeywords start a statement; one of these after a semicolon
+// means a stacked query.
+var sqlStatementKeywords = map[string]bool{
+	"SELECT": true, "INSERT": true, "UPDATE": true, "DELETE": true,
+	"DROP": true, "ALTER": true, "CREATE": true, "EXEC": true,
+	"TRUNCATE": true, "GRANT": true,
+}
+
+// AnalyzeSQL lexes input and returns the injection structures found:
+// union_select, stacked_query, tautology. An empty slice means the lexer
+// saw nothing SQL-injection shaped.
+//
+// Injection payloads usually start by closing a string the surrounding
+// query opened ("' OR '1'='1"), which leaves the payload itself with an
+// unbalanced quote; the lexer would swallow everything after it as one
+// string. The payload is therefore also analyzed with that context-closing
+// quote removed.
+func AnalyzeSQL(input string) []string {
+	findings := analyzeSQLTokens(lexSQL(input))
+	if idx := strings.IndexAny(input, `'"`); idx >= 0 {
+		reinterpreted := input[:idx] + input[idx+1:]
+		for _, finding := range analyzeSQLTokens(lexSQL(reinterpreted)) {
+			findings = appendUnique(findings, finding)
+		}
+	}
+	return findings
+}
+
+func analyzeSQLTokens(tokens []sqlToken) []string {
+	var findings []string
+	add := func(finding string) {
+		findings = appendUnique(findings, finding)
+	}
+
+	for i, token := range tokens {
+		switch {
+		case token.kind == sqlWord && token.text == "UNION":
+			// UNION [ALL|DISTINCT] SELECT
+			j := i + 1
+			if j < len(tokens) && tokens[j].kind == sqlWord &&
+				(tokens[j].text == "ALL" || tokens[j].text == "DISTINCT") {
+				j++
+			}
+			if j < len(tokens) && tokens[j].kind == sqlWord && tokens[j].text == "SELECT" {
+				add("union_select")
+			}
+		case token.kind == sqlSemicolon:
+			if i+1 < len(tokens) && tokens[i+1].kind == sqlWord &&
+				sqlStatementKeywords[tokens[i+1].text] {
+				add("stacked_query")
+			}
+		case token.kind == sqlWord && token.text == "OR":
+			// OR <literal> = <same literal>, the classic tautology.
+			if i+3 < len(tokens) &&
+				literal(tokens[i+1]) && tokens[i+2].kind == sqlOp && tokens[i+2].text == "=" &&
+				literal(tokens[i+3]) && tokens[i+1].text == tokens[i+3].text {
+				add("tautology")
+			}
+		}
+	}
+	return findings
+}
+
+func literal(token sqlToken) bool {
+	return token.kind == sqlString || token.kind == sqlNumber
+}
+
+func appendUnique(findings []string, finding string) []string {
+	for _, existing := range findings {
+		if existing == finding {
+			return findings
+		}
+	}
+	return append(findings, finding)
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,104 @@
+package detector
+
+import (
+	"math"
+	"strings"
+)
+
+// Anomaly heuristics. Adversarial suffixes and other optimizer-generated
+// payloads look like line noise — no regex fires, the pre-classifier calls
+// them benign, and sampling may skip the model entirely. These statistical
+// signals (segment entropy, non-ASCII density, token length shape) do not
+// block anything on their own; a suspicious input is simply forced through
+// to the model stage regardless of pre-classifier and sampling decisions.
+
+// AnomalyConfig tunes the heuristics. Zero values use the defaults.
+type AnomalyConfig struct {
+	Enabled bool
+	// EntropyThreshold flags segments whose Shannon entropy exceeds it,
+	// in bits per byte (default 4.5; English prose sits near 4.1).
+	EntropyThreshold float64
+	// NonASCIIRatio flags inputs with more than this fraction of
+	// non-ASCII bytes (default 0.3).
+	NonASCIIRatio float64
+	// LongTokenLength flags whitespace-delimited tokens at or above this
+	// length (default 30); prose rarely produces them, encoded payloads
+	// and adversarial suffixes do.
+	LongTokenLength int
+}
+
+func (c AnomalyConfig) withDefaults() AnomalyConfig {
+	if c.EntropyThreshold <= 0 {
+		c.EntropyThreshold = 4.5
+	}
+	if c.NonASCIIRatio <= 0 {
+		c.NonASCIIRatio = 0.3
+	}
+	if c.LongTokenLength <= 0 {
+		c.LongTokenLength = 30
+	}
+	return c
+}
+
+// EnableAnomalyHeuristics escalates statistically suspicious inputs to the
+// model even when no regex fires.
+func (d *EnhancedDetector) EnableAnomalyHeuristics(config AnomalyConfig) {
+	config.Enabled = true
+	d.anomaly = config.withDefaults()
+}
+
+// anomalySegmentMin is the shortest segment worth an entropy check; short
+// strings have noisy entropy estimates.
+const anomalySegmentMin = 40
+
+// anomalySignals returns the heuristics the input trips, empty when it
+// looks statistically ordinary.
+func anomalySignals(text string, config AnomalyConfig) []string {
+	var signals []string
+
+	for _, segment := range strings.Split(text, "\n") {
+		segment = strings.TrimSpace(segment)
+		if len(segment) >= anomalySegmentMin && shannonEntropy(segment) > config.EntropyThreshold {
+			signals = append(signals, "high_entropy_segment")
+			break
+		}
+	}
+
+	if len(text) > 0 {
+		nonASCII := 0
+		for i := 0; i < len(text); i++ {
+			if text[i] > 127 {
+				nonASCII++
+			}
+		}
+		if float64(nonASCII)/float64(len(text)) > config.NonASCIIRatio {
+			signals = append(signals, "non_ascii_ratio")
+		}
+	}
+
+	for _, token := range strings.Fields(text) {
+		if len(token) >= config.LongTokenLength {
+			signals = append(signals, "unusual_token_length")
+			break
+		}
+	}
+	return signals
+}
+
+// shannonEntropy is the byte-level Shannon entropy in bits per byte.
+func shannonEntropy(text string) float64 {
+	var counts [256]int
+	for i := 0; i < len(text); i++ {
+		counts[text[i]]++
+	}
+	total := float64(len(text))
+	sum := 0.0
+	for _, count := range counts {
+		if count == 0 {
+			continue
+		}
+		p := float64(count) / total
+		sum -= p * math.Log2(p)
+	}
+	return sum
+}
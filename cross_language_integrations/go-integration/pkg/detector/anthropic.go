@@ -0,0 +1,93 @@
+package detector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// AnthropicBackend classifies inputs via the Anthropic Messages API.
+type AnthropicBackend struct {
+	BaseURL string // e.g. https://api.anthropic.com
+	APIKey  string
+	Model   string // e.g. claude-3-5-haiku-latest
+	Client  *http.Client
+}
+
+// NewAnthropicBackend creates a backend using the Anthropic Messages API.
+func NewAnthropicBackend(baseURL, apiKey, model string) *AnthropicBackend {
+	return &AnthropicBackend{
+		BaseURL: baseURL,
+		APIKey:  apiKey,
+		Model:   model,
+		Client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+type anthropicRequest struct {
+	Model     string             `json:"model"`
+	MaxTokens int                `json:"max_tokens"`
+	Messages  []anthropicMessage `json:"messages"`
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+}
+
+// Classify asks the model to classify the text and parses the JSON reply.
+func (b *AnthropicBackend) Classify(ctx context.Context, text string) (*guard.ThreatResult, error) {
+	reqBody, err := json.Marshal(anthropicRequest{
+		Model:     b.Model,
+		MaxTokens: 512,
+		Messages: []anthropicMessage{
+			{Role: "user", Content: classificationPrompt(text)},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.BaseURL+"/v1/messages", bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", b.APIKey)
+	req.Header.Set("anthropic-version", "2023-06-01")
+
+	resp, err := b.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Anthropic API returned status: %s", resp.Status)
+	}
+
+	var apiResp anthropicResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, err
+	}
+	for _, block := range apiResp.Content {
+		if block.Type == "text" {
+			return parseClassification(block.Text)
+		}
+	}
+	return nil, fmt.Errorf("Anthropic API returned no text content")
+}
@@ -0,0 +1,110 @@
+package detector
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// AuthConfig holds the credentials and transport settings needed to call
+// model endpoints behind auth: hosted inference providers, internal
+// services requiring mTLS, or endpoints reachable only through a proxy.
+type AuthConfig struct {
+	// BearerToken is sent as "Authorization: Bearer <token>".
+	BearerToken string `json:"bearer_token,omitempty"`
+	// APIKeyHeader/APIKey send the key in a provider-specific header,
+	// e.g. "X-API-Key".
+	APIKeyHeader string `json:"api_key_header,omitempty"`
+	APIKey       string `json:"api_key,omitempty"`
+	// Headers are additional headers added to every request.
+	Headers map[string]string `json:"headers,omitempty"`
+	// ClientCertFile/ClientKeyFile enable an mTLS client certificate.
+	ClientCertFile string `json:"client_cert_file,omitempty"`
+	ClientKeyFile  string `json:"client_key_file,omitempty"`
+	// CAFile adds a CA bundle for verifying the endpoint's certificate.
+	CAFile string `json:"ca_file,omitempty"`
+	// ProxyURL routes requests through an HTTP(S) proxy.
+	ProxyURL string `json:"proxy_url,omitempty"`
+}
+
+// authTransport injects auth headers around a base RoundTripper.
+type authTransport struct {
+	base   http.RoundTripper
+	config AuthConfig
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	clone := req.Clone(req.Context())
+	if t.config.BearerToken != "" {
+		clone.Header.Set("Authorization", "Bearer "+t.config.BearerToken)
+	}
+	if t.config.APIKeyHeader != "" && t.config.APIKey != "" {
+		clone.Header.Set(t.config.APIKeyHeader, t.config.APIKey)
+	}
+	for name, value := range t.config.Headers {
+		clone.Header.Set(name, value)
+	}
+	return t.base.RoundTrip(clone)
+}
+
+// buildTransport assembles the TLS and proxy settings into a transport.
+func (c AuthConfig) buildTransport() (http.RoundTripper, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if c.ProxyURL != "" {
+		proxy, err := url.Parse(c.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	tlsConfig := &tls.Config{}
+	if c.ClientCertFile != "" || c.ClientKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.ClientCertFile, c.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", c.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	return &authTransport{base: transport, config: c}, nil
+}
+
+// SetAuth applies auth settings to the model backend's HTTP client. It
+// works on any backend in this package that exposes its client; wrapped
+// backends (resilience, hedging, recording) must be configured before
+// wrapping.
+func (d *EnhancedDetector) SetAuth(config AuthConfig) error {
+	backend, ok := d.backend.(*HTTPBackend)
+	if !ok {
+		return fmt.Errorf("SetAuth requires an HTTP model backend (call before wrapping)")
+	}
+	return backend.SetAuth(config)
+}
+
+// SetAuth applies auth settings to this backend's HTTP client.
+func (b *HTTPBackend) SetAuth(config AuthConfig) error {
+	transport, err := config.buildTransport()
+	if err != nil {
+		return err
+	}
+	b.Client.Transport = transport
+	return nil
+}
@@ -0,0 +1,109 @@
+package detector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// ModelBackend abstracts the model stage of the pipeline so providers can be
+// swapped without touching the detector. Implementations classify a single
+// input and return a ThreatResult.
+type ModelBackend interface {
+	Classify(ctx context.Context, text string) (*guard.ThreatResult, error)
+}
+
+// HTTPBackend calls the original Python model API (POST /detect).
+type HTTPBackend struct {
+	Endpoint string
+	Client   *http.Client
+}
+
+// NewHTTPBackend creates a backend for the existing model HTTP API.
+func NewHTTPBackend(endpoint string) *HTTPBackend {
+	return &HTTPBackend{
+		Endpoint: endpoint,
+		Client: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+}
+
+// Classify sends the text to the /detect endpoint and decodes the result.
+func (b *HTTPBackend) Classify(ctx context.Context, text string) (*guard.ThreatResult, error) {
+	reqBody, err := json.Marshal(apiRequest{Text: text})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.Endpoint+"/detect", bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status: %s", resp.Status)
+	}
+
+	var result guard.ThreatResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// classificationPrompt builds the instruction given to chat-style backends
+// (OpenAI, Anthropic, Ollama). It reuses the field schema from the guard
+// package so all backends share one taxonomy.
+func classificationPrompt(text string) string {
+	var sb strings.Builder
+	sb.WriteString(guard.Instructions)
+	sb.WriteString("\n\nRespond with a single JSON object with these fields:\n")
+	sb.WriteString(`{"is_threat": bool, "threat_type": string, "confidence": number, "reasoning": string}`)
+	sb.WriteString("\n\nValid threat types: ")
+	for _, f := range guard.PromptFields {
+		if f.Name == "threat_type" {
+			sb.WriteString(f.Description)
+		}
+	}
+	sb.WriteString("\n\nInput Text: ")
+	sb.WriteString(text)
+	return sb.String()
+}
+
+// parseClassification extracts a ThreatResult from a chat model reply. Models
+// sometimes wrap the JSON in prose or code fences, so we look for the first
+// balanced JSON object.
+func parseClassification(reply string) (*guard.ThreatResult, error) {
+	start := strings.Index(reply, "{")
+	end := strings.LastIndex(reply, "}")
+	if start < 0 || end <= start {
+		return nil, fmt.Errorf("no JSON object in model reply: %q", reply)
+	}
+
+	var result guard.ThreatResult
+	if err := json.Unmarshal([]byte(reply[start:end+1]), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse model reply: %w", err)
+	}
+	if result.ThreatType == "" {
+		result.ThreatType = "benign"
+	}
+	if result.Severity == "" {
+		result.Severity = guard.SeverityForType(result.ThreatType)
+	}
+	return &result, nil
+}
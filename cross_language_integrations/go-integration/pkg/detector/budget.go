@@ -0,0 +1,21 @@
+package detector
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// DetectWithBudget runs the pipeline under a hard latency budget. The regex
+// stages always complete; if the model stage cannot finish inside the
+// budget, the call returns the regex-only verdict annotated as degraded
+// instead of holding up the request path. A chat frontend with a 150ms
+// budget therefore never waits out a slow model timeout:
+//
+//	result, err := det.DetectWithBudget(ctx, text, 150*time.Millisecond)
+func (d *EnhancedDetector) DetectWithBudget(ctx context.Context, text string, budget time.Duration) (*guard.ThreatResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, budget)
+	defer cancel()
+	return d.DetectContext(ctx, text)
+}
@@ -0,0 +1,141 @@
+package detector
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// CacheConfig controls the optional result cache. Identical inputs (common
+// greetings, repeated health checks) then skip the model stage entirely.
+type CacheConfig struct {
+	// TTL is how long a cached result stays valid.
+	TTL time.Duration
+	// MaxEntries bounds the cache; the least recently used entry is evicted.
+	MaxEntries int
+}
+
+type cacheEntry struct {
+	key       string
+	result    guard.ThreatResult
+	expiresAt time.Time
+}
+
+// TenantCacheStats are cumulative per-tenant hit/miss counts.
+type TenantCacheStats struct {
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+}
+
+// resultCache is a TTL + LRU cache keyed on (tenant, policy version,
+// normalized text hash) so hot-reloads and per-tenant policies never serve
+// a decision made under another configuration.
+type resultCache struct {
+	mu      sync.Mutex
+	config  CacheConfig
+	entries map[string]*list.Element
+	order   *list.List // front = most recently used
+
+	hits   uint64
+	misses uint64
+	tenant map[string]*TenantCacheStats
+}
+
+func newResultCache(config CacheConfig) *resultCache {
+	return &resultCache{
+		config:  config,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+		tenant:  make(map[string]*TenantCacheStats),
+	}
+}
+
+// cacheKey combines tenant, policy/rule-set version, and a hash of the
+// normalized input. Hashing means equivalent obfuscated variants share an
+// entry and raw prompts are not kept in memory.
+func cacheKey(tenant, policyVersion, text string) string {
+	sum := sha256.Sum256([]byte(guard.Normalize(text).Text))
+	return tenant + "\x00" + policyVersion + "\x00" + hex.EncodeToString(sum[:])
+}
+
+func (c *resultCache) tenantStats(tenant string) *TenantCacheStats {
+	stats, ok := c.tenant[tenant]
+	if !ok {
+		stats = &TenantCacheStats{}
+		c.tenant[tenant] = stats
+	}
+	return stats
+}
+
+func (c *resultCache) get(tenant, key string) (*guard.ThreatResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		c.tenantStats(tenant).Misses++
+		return nil, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		c.misses++
+		c.tenantStats(tenant).Misses++
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	c.hits++
+	c.tenantStats(tenant).Hits++
+	result := entry.result
+	return &result, true
+}
+
+func (c *resultCache) put(key string, result *guard.ThreatResult) {
+	if result == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		entry.result = *result
+		entry.expiresAt = time.Now().Add(c.config.TTL)
+		c.order.MoveToFront(elem)
+		return
+	}
+	for c.config.MaxEntries > 0 && len(c.entries) >= c.config.MaxEntries {
+		oldest := c.order.Back()
+		if oldest == nil {
+			break
+		}
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+	elem := c.order.PushFront(&cacheEntry{
+		key:       key,
+		result:    *result,
+		expiresAt: time.Now().Add(c.config.TTL),
+	})
+	c.entries[key] = elem
+}
+
+// stats returns cumulative hit/miss counts.
+func (c *resultCache) stats() (hits, misses uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
+
+// statsForTenant returns cumulative hit/miss counts for one tenant.
+func (c *resultCache) statsForTenant(tenant string) TenantCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return *c.tenantStats(tenant)
+}
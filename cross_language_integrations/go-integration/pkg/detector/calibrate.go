@@ -0,0 +1,100 @@
+package detector
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Score calibration. The stages emit incomparable numbers: the regex stage
+// uses fixed 0.5/0.95 steps, the model emits whatever its softmax produces,
+// fingerprint similarity is a distance rescale. A policy threshold of 0.7
+// therefore means something different per stage. Calibration maps each
+// stage's raw score onto one probability scale with per-stage Platt
+// scaling, fitted offline from labeled eval data, so thresholds hold their
+// meaning regardless of which stage produced the verdict.
+
+// StageCalibration holds Platt parameters for one stage: the calibrated
+// probability is 1 / (1 + exp(A*raw + B)). The zero value is not identity;
+// stages without an entry stay uncalibrated.
+type StageCalibration struct {
+	A float64 `json:"a"`
+	B float64 `json:"b"`
+}
+
+// apply maps a raw score through the fitted sigmoid.
+func (c StageCalibration) apply(raw float64) float64 {
+	return 1 / (1 + math.Exp(c.A*raw+c.B))
+}
+
+// Calibration maps stage names (regex, fingerprint, model) to their fitted
+// parameters.
+type Calibration map[string]StageCalibration
+
+// LoadCalibration reads a calibration file produced by an offline fit.
+func LoadCalibration(path string) (Calibration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cal Calibration
+	if err := json.Unmarshal(data, &cal); err != nil {
+		return nil, err
+	}
+	return cal, nil
+}
+
+// EnableCalibration rescales each stage's confidence onto the common
+// probability scale before thresholds and fusion see it.
+func (d *EnhancedDetector) EnableCalibration(cal Calibration) {
+	d.calibration = cal
+}
+
+// calibrate rewrites a stage result's confidence in place. Stages without
+// fitted parameters pass through untouched.
+func (d *EnhancedDetector) calibrate(stage string, result *guard.ThreatResult) {
+	if d.calibration == nil || result == nil {
+		return
+	}
+	params, ok := d.calibration[stage]
+	if !ok {
+		return
+	}
+	result.Confidence = params.apply(result.Confidence)
+}
+
+// FitPlatt fits Platt parameters from raw stage scores and their labels
+// (true = the input really was a threat), by gradient descent on the
+// logistic loss. Use it offline against eval data and persist the result
+// for LoadCalibration.
+func FitPlatt(scores []float64, labels []bool) StageCalibration {
+	// Start near an identity-like mapping (descending sigmoid in -raw).
+	a, b := -1.0, 0.5
+	const (
+		iterations = 2000
+		rate       = 0.1
+	)
+	n := float64(len(scores))
+	if n == 0 {
+		return StageCalibration{A: a, B: b}
+	}
+	for iter := 0; iter < iterations; iter++ {
+		var gradA, gradB float64
+		for i, score := range scores {
+			p := 1 / (1 + math.Exp(a*score+b))
+			y := 0.0
+			if labels[i] {
+				y = 1.0
+			}
+			// d(loss)/d(a*score+b) = p - y, with the sigmoid's sign folded in.
+			delta := y - p
+			gradA += delta * score
+			gradB += delta
+		}
+		a -= rate * gradA / n
+		b -= rate * gradB / n
+	}
+	return StageCalibration{A: a, B: b}
+}
@@ -0,0 +1,106 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Chunked scanning. A 100KB document pasted into chat makes CheckRegex
+// crawl and blows straight past any model context limit. With chunking
+// enabled, oversized inputs are split into overlapping windows that each
+// run the normal pipeline; the overlap guarantees that an attack phrase
+// straddling a chunk boundary still appears whole in one of the two
+// neighbouring chunks, so splitting the input cannot be used to hide it.
+
+// ChunkingConfig controls the sliding window. Zero values use the
+// defaults.
+type ChunkingConfig struct {
+	Enabled bool
+	// ChunkSize is the window size in bytes (default 8192). Inputs at or
+	// under it are scanned in one piece.
+	ChunkSize int
+	// Overlap is how many bytes consecutive chunks share (default 512).
+	// Any attack phrase shorter than this survives intact in at least one
+	// chunk.
+	Overlap int
+}
+
+func (c ChunkingConfig) withDefaults() ChunkingConfig {
+	if c.ChunkSize <= 0 {
+		c.ChunkSize = 8192
+	}
+	if c.Overlap <= 0 {
+		c.Overlap = 512
+	}
+	if c.Overlap >= c.ChunkSize {
+		c.Overlap = c.ChunkSize / 2
+	}
+	return c
+}
+
+// EnableChunking makes the detector split oversized inputs into
+// overlapping windows automatically.
+func (d *EnhancedDetector) EnableChunking(config ChunkingConfig) {
+	config.Enabled = true
+	d.chunking = config.withDefaults()
+}
+
+// chunkText slices text into windows of at most size bytes where
+// consecutive windows share overlap bytes. Boundaries are nudged back to
+// rune starts so no chunk begins or ends mid-character.
+func chunkText(text string, size, overlap int) []string {
+	var chunks []string
+	step := size - overlap
+	for start := 0; start < len(text); start += step {
+		for start < len(text) && !utf8.RuneStart(text[start]) {
+			start++
+		}
+		end := start + size
+		if end >= len(text) {
+			chunks = append(chunks, text[start:])
+			break
+		}
+		for end > start && !utf8.RuneStart(text[end]) {
+			end--
+		}
+		chunks = append(chunks, text[start:end])
+	}
+	return chunks
+}
+
+// detectChunked runs the pipeline per chunk and aggregates: the strongest
+// threat verdict wins, and a high-confidence hit stops the scan early.
+// Each chunk is at most ChunkSize bytes, so the per-chunk calls never
+// re-enter this path.
+func (d *EnhancedDetector) detectChunked(ctx context.Context, tenant, text string) (*guard.ThreatResult, error) {
+	chunks := chunkText(text, d.chunking.ChunkSize, d.chunking.Overlap)
+	var strongest *guard.ThreatResult
+	for i, chunk := range chunks {
+		result, err := d.detect(ctx, tenant, chunk)
+		if err != nil {
+			return nil, fmt.Errorf("chunk %d/%d: %w", i+1, len(chunks), err)
+		}
+		if !result.IsThreat {
+			continue
+		}
+		result.Reasoning = fmt.Sprintf("Chunk %d/%d: %s", i+1, len(chunks), result.Reasoning)
+		if strongest == nil || result.Confidence > strongest.Confidence {
+			strongest = result
+		}
+		if strongest.Confidence >= 0.9 {
+			break
+		}
+	}
+	if strongest != nil {
+		return strongest, nil
+	}
+	return &guard.ThreatResult{
+		IsThreat:   false,
+		ThreatType: "benign",
+		Confidence: 0.8,
+		Reasoning:  fmt.Sprintf("All %d chunks clean", len(chunks)),
+	}, nil
+}
@@ -0,0 +1,49 @@
+package detector
+
+import (
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+	"github.com/marcusjohansson/trust-go/pkg/guardmetrics"
+)
+
+// Cost accounting for the model stage: tokens are estimated from the text
+// actually sent to the backend (after reduction), priced with a configured
+// rate, aggregated per tenant in metrics, and optionally attached to each
+// verdict. The estimate uses the usual ~4 bytes/token heuristic — close
+// enough for attribution and budgeting, not for invoicing.
+
+// CostConfig controls model-stage cost tracking.
+type CostConfig struct {
+	Enabled bool
+	// CostPer1KTokens prices estimated input tokens, typically in USD
+	// (e.g. 0.0001 for a small classifier).
+	CostPer1KTokens float64
+}
+
+// EnableCostTracking attributes model-stage token usage and estimated cost
+// per tenant via metrics and attaches a Usage field to verdicts that ran
+// the model.
+func (d *EnhancedDetector) EnableCostTracking(config CostConfig) {
+	config.Enabled = true
+	d.cost = config
+}
+
+// estimateTokens approximates the token count of a model input.
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// recordCost accounts one model call against the tenant and returns the
+// usage to attach to the verdict (nil when tracking is disabled).
+func (d *EnhancedDetector) recordCost(tenant, modelInput string) *guard.Usage {
+	if !d.cost.Enabled {
+		return nil
+	}
+	if tenant == "" {
+		tenant = "default"
+	}
+	tokens := estimateTokens(modelInput)
+	cost := float64(tokens) / 1000 * d.cost.CostPer1KTokens
+	guardmetrics.ModelTokensTotal.WithLabelValues(tenant).Add(float64(tokens))
+	guardmetrics.ModelCostTotal.WithLabelValues(tenant).Add(cost)
+	return &guard.Usage{InputTokens: tokens, EstimatedCost: cost}
+}
@@ -1,52 +1,438 @@
 package detector
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"net/http"
+	"os"
+	"sync/atomic"
 	"time"
 
+	"github.com/marcusjohansson/trust-go/pkg/audit"
+	"github.com/marcusjohansson/trust-go/pkg/codescan"
+	"github.com/marcusjohansson/trust-go/pkg/fingerprint"
 	"github.com/marcusjohansson/trust-go/pkg/guard"
+	"github.com/marcusjohansson/trust-go/pkg/guardmetrics"
+	"github.com/marcusjohansson/trust-go/pkg/lang"
+	"github.com/marcusjohansson/trust-go/pkg/notify"
+	"github.com/marcusjohansson/trust-go/pkg/rules"
+	"github.com/marcusjohansson/trust-go/pkg/signing"
 )
 
-// EnhancedDetector implements the full hybrid threat detection pipeline
+// EnhancedDetector implements the full hybrid threat detection pipeline.
+//
+// Concurrency contract: one detector instance is meant to be shared by any
+// number of goroutines. The Detect* methods are safe for concurrent use,
+// as are SetPolicyVersion, ReportFalsePositive, the stats accessors, and
+// the guard package's runtime registration functions (RegisterThreatType,
+// RemovePattern, ReloadPatterns). The Enable*/Set* configuration methods
+// are not synchronized against in-flight detections — call them during
+// startup, before the detector is shared.
 type EnhancedDetector struct {
-	configPath    string
-	regexPath     string
-	modelEndpoint string
-	client        *http.Client
+	configPath string
+	regexPath  string
+	backend    ModelBackend
+	cache      *resultCache
+	// policyVersion is mixed into cache keys; bump it on policy or rule-set
+	// hot-reloads so stale decisions are never served. It holds a string
+	// and is atomic because reloads race with in-flight cache lookups.
+	policyVersion atomic.Value
+	// differential, when set, records regex-vs-hybrid disagreements
+	// (see EnableDifferential).
+	differential *differentialRecorder
+	// fusion controls how regex and model verdicts are combined (see SetFusion).
+	fusion FusionConfig
+	// shadow selects traffic that is evaluated but never blocked (see SetShadow).
+	shadow ShadowConfig
+	// auditLogger, when set, receives every decision (see EnableAudit).
+	auditLogger       audit.Logger
+	auditCaptureInput bool
+	// notifier, when set, receives blocked high-severity threats (see SetNotifier).
+	notifier *notify.Notifier
+	// preclassifier skips the model stage for trivially benign inputs
+	// (see EnablePreClassifier).
+	preclassifier PreClassifierConfig
+	// allowTrace attaches a "why allowed" explanation to benign verdicts
+	// (see EnableAllowTrace).
+	allowTrace bool
+	// fingerprints, when set, short-circuits near-duplicates of confirmed
+	// malicious prompts (see EnableFingerprints).
+	fingerprints *fingerprint.Store
+	// reduce shrinks long inputs before the model stage (see EnableReduce).
+	reduce ReduceConfig
+	// ruleSet, when set, evaluates YARA-like composite rules alongside the
+	// regex pre-filter (see EnableRules).
+	ruleSet *rules.Set
+	// feedback, when set, suppresses or down-weights verdicts reviewed as
+	// false positives (see EnableFeedback).
+	feedback *SuppressionStore
+	// ring, when set, buffers recent decisions for live tailing
+	// (see EnableDecisionRing).
+	ring *DecisionRing
+	// sampling sends only a fraction of regex-clean inputs to the model
+	// (see SetSampling).
+	sampling SamplingConfig
+	// cost attributes model token usage per tenant (see EnableCostTracking).
+	cost CostConfig
+	// calibration rescales per-stage confidences onto one probability scale
+	// (see EnableCalibration).
+	calibration Calibration
+	// multilingual, when set, runs per-language pattern packs beside the
+	// English patterns (see EnableMultilingual).
+	multilingual *lang.Scanner
+	// structured scans concealed HTML/Markdown regions separately
+	// (see EnableContentExtraction).
+	structured bool
+	// deepCode lexes suspected SQL/shell payloads for injection structure
+	// (see EnableDeepCodeAnalysis).
+	deepCode bool
+	// anomaly escalates statistically suspicious inputs to the model
+	// (see EnableAnomalyHeuristics).
+	anomaly AnomalyConfig
+	// chunking splits oversized inputs into overlapping windows
+	// (see EnableChunking).
+	chunking ChunkingConfig
 }
 
-// NewEnhanced creates a new enhanced detector
+// NewEnhanced creates a new enhanced detector using the default HTTP model API
 func NewEnhanced(configPath, regexPath, modelEndpoint string) (*EnhancedDetector, error) {
+	return NewEnhancedWithBackend(configPath, regexPath, NewHTTPBackend(modelEndpoint))
+}
+
+// NewEnhancedWithBackend creates a new enhanced detector with a custom model backend
+func NewEnhancedWithBackend(configPath, regexPath string, backend ModelBackend) (*EnhancedDetector, error) {
+	if backend == nil {
+		return nil, fmt.Errorf("model backend must not be nil")
+	}
 	return &EnhancedDetector{
-		configPath:    configPath,
-		regexPath:     regexPath,
-		modelEndpoint: modelEndpoint,
-		client: &http.Client{
-			Timeout: 5 * time.Second,
-		},
+		configPath: configPath,
+		regexPath:  regexPath,
+		backend:    backend,
+		fusion:     DefaultFusionConfig(),
 	}, nil
 }
 
+// VerifyBundles checks the detector's config and regex bundle files against
+// their detached Ed25519 signatures (see pkg/signing and cmd/guard-sign).
+// A bundle that exists but is unsigned or tampered with fails verification;
+// deployments enforcing signing should refuse to start on error.
+func (d *EnhancedDetector) VerifyBundles(publicKeyB64 string) error {
+	for _, path := range []string{d.configPath, d.regexPath} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			continue
+		}
+		if err := signing.VerifyFile(path, publicKeyB64); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EnableCache turns on result caching with the given TTL and size limit.
+// Sensitive tenants can bypass it per call via DetectUncached.
+func (d *EnhancedDetector) EnableCache(config CacheConfig) {
+	d.cache = newResultCache(config)
+}
+
+// SetPolicyVersion records the active policy/rule-set version. Changing it
+// invalidates all cached decisions made under the previous version. It is
+// safe to call while detections are in flight.
+func (d *EnhancedDetector) SetPolicyVersion(version string) {
+	d.policyVersion.Store(version)
+}
+
+// CacheStats returns cumulative cache hits and misses (zero when caching
+// is disabled).
+func (d *EnhancedDetector) CacheStats() (hits, misses uint64) {
+	if d.cache == nil {
+		return 0, 0
+	}
+	return d.cache.stats()
+}
+
+// TenantCacheStats returns cache hit/miss counts for one tenant.
+func (d *EnhancedDetector) TenantCacheStats(tenant string) TenantCacheStats {
+	if d.cache == nil {
+		return TenantCacheStats{}
+	}
+	return d.cache.statsForTenant(tenant)
+}
+
+// EnableAudit sends every detection decision to logger. Records carry an
+// input hash; captureInput additionally stores the raw input in each record.
+func (d *EnhancedDetector) EnableAudit(logger audit.Logger, captureInput bool) {
+	d.auditLogger = logger
+	d.auditCaptureInput = captureInput
+}
+
+// EnableAllowTrace attaches an explanation to every benign verdict: which
+// stages ran, the regex score vector, and the margin to the nearest
+// threshold. It adds a second regex scoring pass per allowed input, so it
+// is meant for false-negative investigations rather than steady state.
+func (d *EnhancedDetector) EnableAllowTrace() {
+	d.allowTrace = true
+}
+
+// EnableFingerprints adds a fuzzy fingerprint lookup stage after the regex
+// pre-filter. Inputs within the store's Hamming distance of a confirmed
+// malicious prompt are blocked without a model call; confirmed attacks are
+// added at runtime via the returned store.
+func (d *EnhancedDetector) EnableFingerprints(maxDistance int) *fingerprint.Store {
+	d.fingerprints = fingerprint.NewStore(maxDistance)
+	return d.fingerprints
+}
+
+// EnableDeepCodeAnalysis lexes suspected SQL and shell payloads (see
+// pkg/codescan). Confirmed injection structure short-circuits at high
+// confidence; shallow code_injection regex hits the lexers cannot confirm
+// are down-weighted instead of blocking outright.
+func (d *EnhancedDetector) EnableDeepCodeAnalysis() {
+	d.deepCode = true
+}
+
+// EnableMultilingual detects each input's language and runs the matching
+// per-language pattern pack (see pkg/lang) beside the English patterns.
+func (d *EnhancedDetector) EnableMultilingual() {
+	d.multilingual = lang.NewScanner()
+}
+
+// EnableRules evaluates a compiled YARA-like rule set (see pkg/rules) as
+// part of the pre-filter stage. Rule hits behave like regex hits: high
+// confidence short-circuits the model, lower confidence feeds into fusion.
+func (d *EnhancedDetector) EnableRules(set *rules.Set) {
+	d.ruleSet = set
+}
+
+// SetNotifier routes blocked high-severity threats to webhook targets.
+func (d *EnhancedDetector) SetNotifier(n *notify.Notifier) {
+	d.notifier = n
+}
+
 // Detect performs full threat detection
 func (d *EnhancedDetector) Detect(text string) (*guard.ThreatResult, error) {
+	return d.DetectContext(context.Background(), text)
+}
+
+// DetectContext performs full threat detection, honoring the given context
+// for the model stage
+func (d *EnhancedDetector) DetectContext(ctx context.Context, text string) (*guard.ThreatResult, error) {
+	return d.DetectTenant(ctx, "", text)
+}
+
+// DetectTenant performs full threat detection on behalf of a tenant, so
+// cached decisions are scoped to that tenant's configuration.
+func (d *EnhancedDetector) DetectTenant(ctx context.Context, tenant, text string) (*guard.ThreatResult, error) {
+	if d.cache != nil {
+		version, _ := d.policyVersion.Load().(string)
+		key := cacheKey(tenant, version, text)
+		if result, ok := d.cache.get(tenant, key); ok {
+			guardmetrics.CacheHitsTotal.Inc()
+			return result, nil
+		}
+		guardmetrics.CacheMissesTotal.Inc()
+		result, err := d.detect(ctx, tenant, text)
+		if err == nil {
+			d.cache.put(key, result)
+		}
+		return result, err
+	}
+	return d.detect(ctx, tenant, text)
+}
+
+// DetectUncached runs the pipeline while bypassing the result cache, for
+// tenants whose inputs must not be retained even as hashes.
+func (d *EnhancedDetector) DetectUncached(ctx context.Context, text string) (*guard.ThreatResult, error) {
+	return d.detect(ctx, "", text)
+}
+
+func (d *EnhancedDetector) detect(ctx context.Context, tenant, text string) (result *guard.ThreatResult, err error) {
+	// Oversized inputs run the pipeline per overlapping chunk instead;
+	// each chunk re-enters detect below the size threshold.
+	if d.chunking.Enabled && len(text) > d.chunking.ChunkSize {
+		return d.detectChunked(ctx, tenant, text)
+	}
+	guardmetrics.RequestsTotal.Inc()
+	detectStart := time.Now()
+	var regexResult *guard.ThreatResult
+	var usage *guard.Usage
+	stage := "model"
+	defer func() {
+		guardmetrics.DetectionLatency.Observe(time.Since(detectStart).Seconds())
+		guardmetrics.DetectionLatencyDetail.WithLabelValues(stage, guardmetrics.SizeBucket(len(text))).
+			Observe(time.Since(detectStart).Seconds())
+		if usage != nil && err == nil && result != nil {
+			result.Usage = usage
+		}
+		if d.feedback != nil && err == nil {
+			result = d.feedback.apply(text, result)
+		}
+		if d.allowTrace && err == nil && result != nil && !result.IsThreat {
+			result.Trace = guard.BuildAllowTrace(text, d.stagesRun(stage), modelConfidence(stage, result), guard.DefaultPolicy())
+		}
+		if d.ring != nil {
+			d.ring.recordDecision(detectStart, text, stage, result, err)
+		}
+		if d.differential != nil && err == nil {
+			d.differential.record(text, regexResult, result)
+		}
+		if d.auditLogger != nil && err == nil {
+			record := audit.Record{
+				Timestamp: detectStart,
+				InputHash: audit.HashInput(text),
+				Verdict:   result,
+				Stage:     stage,
+				LatencyMS: float64(time.Since(detectStart).Microseconds()) / 1000,
+			}
+			if d.auditCaptureInput {
+				record.Input = text
+			}
+			d.auditLogger.Log(record)
+		}
+	}()
+
 	// Stage 1: Regex Pre-filter
-	regexResult := guard.CheckRegex(text)
+	regexResult = guard.CheckRegex(text)
+	if regexResult == nil {
+		// Look for payloads hidden behind base64/hex/URL encoding
+		regexResult = guard.CheckEncoded(text)
+	}
+	// Composite rules run beside the flat patterns; the stronger verdict
+	// of the two carries forward.
+	if d.ruleSet != nil {
+		if ruleResult := d.ruleSet.Scan(text); ruleResult != nil {
+			if regexResult == nil || ruleResult.Confidence > regexResult.Confidence {
+				regexResult = ruleResult
+			}
+		}
+	}
+	// Non-English inputs get their language's pattern pack.
+	if d.multilingual != nil {
+		if langResult := d.multilingual.Scan(text); langResult != nil {
+			if regexResult == nil || langResult.Confidence > regexResult.Confidence {
+				regexResult = langResult
+			}
+		}
+	}
+	// Concealed markup regions (comments, alt text, hidden elements) are
+	// scanned on their own; hidden instructions out-rank whatever the full
+	// document scored.
+	if d.structured {
+		if hiddenResult := scanHiddenRegions(text); hiddenResult != nil {
+			if regexResult == nil || hiddenResult.Confidence > regexResult.Confidence {
+				regexResult = hiddenResult
+			}
+		}
+	}
+	// Deep code analysis both escalates (lexer-confirmed injection
+	// structure) and de-escalates (a shallow code_injection hit the lexers
+	// cannot confirm).
+	if d.deepCode {
+		if deep := codescan.Scan(text); deep != nil {
+			if regexResult == nil || deep.Confidence > regexResult.Confidence {
+				regexResult = deep
+			}
+		} else if regexResult != nil && regexResult.ThreatType == "code_injection" && regexResult.Confidence < 0.9 {
+			regexResult.Confidence /= 2
+			regexResult.Reasoning += " (not confirmed by lexical analysis)"
+		}
+	}
+	d.calibrate("regex", regexResult)
 	if regexResult != nil && regexResult.Confidence >= 0.9 {
 		// High confidence regex match (blocking)
+		stage = "regex"
+		guardmetrics.DetectionsTotal.WithLabelValues(regexResult.ThreatType, "regex").Inc()
 		return regexResult, nil
 	}
 
-	// Stage 2: API Call to Model
-	apiResult, err := d.callModelAPI(text)
+	// Fingerprint lookup: near-duplicates of confirmed attacks skip the model.
+	if d.fingerprints != nil {
+		if known := d.fingerprints.Lookup(text); known != nil {
+			d.calibrate("fingerprint", known)
+			stage = "fingerprint"
+			guardmetrics.DetectionsTotal.WithLabelValues(known.ThreatType, "fingerprint").Inc()
+			return known, nil
+		}
+	}
+
+	// Anomaly heuristics: gibberish-looking inputs (adversarial suffixes,
+	// encoded blobs) must reach the model even though no regex fired, so
+	// they disable the pre-classifier and sampling skips below.
+	var anomalies []string
+	if d.anomaly.Enabled && regexResult == nil {
+		anomalies = anomalySignals(text, d.anomaly)
+	}
+
+	// Benign pre-classifier: skip the model for trivially benign inputs.
+	if regexResult == nil && len(anomalies) == 0 {
+		if benign := d.preclassify(text); benign != nil {
+			stage = "preclassifier"
+			guardmetrics.DetectionsTotal.WithLabelValues("benign", "preclassifier").Inc()
+			return benign, nil
+		}
+	}
+
+	// Cost sampling: regex-clean inputs outside the sampled fraction skip
+	// the model. Anything regex-flagged always escalates.
+	if regexResult == nil && len(anomalies) == 0 && d.sampledOut(text) {
+		stage = "sampling"
+		guardmetrics.DetectionsTotal.WithLabelValues("benign", "sampling").Inc()
+		return &guard.ThreatResult{
+			IsThreat:   false,
+			ThreatType: "benign",
+			Confidence: 0.5,
+			Reasoning:  "Regex-clean; model stage skipped by cost sampling",
+		}, nil
+	}
+
+	// Stage 2: Model Backend
+	modelStart := time.Now()
+	modelInput := d.reduceForModel(text)
+	apiResult, err := d.backend.Classify(ctx, modelInput)
+	guardmetrics.ModelLatency.Observe(time.Since(modelStart).Seconds())
+	if err == nil {
+		usage = d.recordCost(tenant, modelInput)
+		d.calibrate("model", apiResult)
+	}
 	if err != nil {
+		guardmetrics.ModelErrorsTotal.Inc()
+		// A deadline hit is a budget decision, not an outage: degrade to
+		// the regex-only verdict instead of blocking the request path.
+		degraded := ctx.Err() == context.DeadlineExceeded
 		// Fallback to regex result if available, otherwise return error
 		if regexResult != nil {
-			regexResult.Reasoning += " (API Unavailable)"
+			if degraded {
+				regexResult.Reasoning += " (degraded: latency budget exceeded)"
+				stage = "degraded"
+			} else {
+				regexResult.Reasoning += " (API Unavailable)"
+				stage = "regex"
+			}
+			guardmetrics.DetectionsTotal.WithLabelValues(regexResult.ThreatType, stage).Inc()
 			return regexResult, nil
 		}
+		stage = "fallback"
+		// During lockdown the pipeline fails closed: no model verdict means
+		// the input is treated as a threat.
+		if guard.InLockdown() {
+			return &guard.ThreatResult{
+				IsThreat:   true,
+				ThreatType: "adversarial_input",
+				Confidence: 1.0,
+				Reasoning:  fmt.Sprintf("Lockdown fail-closed: model unavailable (%v)", err),
+				Severity:   guard.SeverityCritical,
+			}, nil
+		}
+		if degraded {
+			stage = "degraded"
+			return &guard.ThreatResult{
+				IsThreat:   false,
+				ThreatType: "benign",
+				Confidence: 0.0,
+				Reasoning:  "Degraded: latency budget exceeded before a model verdict",
+			}, nil
+		}
 		// If API fails and no regex match, treat as benign but warn
 		return &guard.ThreatResult{
 			IsThreat:   false,
@@ -58,49 +444,65 @@ func (d *EnhancedDetector) Detect(text string) (*guard.ThreatResult, error) {
 
 	// Stage 3: Fusion Logic
 	if regexResult != nil && regexResult.IsThreat {
-		// If regex found something but API didn't, or API confidence is low
-		// For safety, we might trust regex if it was a clear match
+		fused := d.fusion.fuse(regexResult, apiResult)
 		if !apiResult.IsThreat {
-			return &guard.ThreatResult{
-				IsThreat:   true,
-				ThreatType: regexResult.ThreatType,
-				Confidence: 0.5, // Lower confidence since model disagreed
-				Reasoning:  fmt.Sprintf("Regex match '%s' (Model disagreed)", regexResult.Reasoning),
-			}, nil
+			stage = "regex"
 		}
+		guardmetrics.DetectionsTotal.WithLabelValues(fused.ThreatType, stage).Inc()
+		return fused, nil
 	}
 
+	guardmetrics.DetectionsTotal.WithLabelValues(apiResult.ThreatType, "model").Inc()
 	return apiResult, nil
 }
 
-type apiRequest struct {
-	Text string `json:"text"`
-}
-
-func (d *EnhancedDetector) callModelAPI(text string) (*guard.ThreatResult, error) {
-	reqBody, err := json.Marshal(apiRequest{Text: text})
-	if err != nil {
-		return nil, err
+// stagesRun reconstructs which pipeline stages executed from the stage
+// that produced the verdict.
+func (d *EnhancedDetector) stagesRun(stage string) []string {
+	stages := []string{"regex"}
+	if d.fingerprints != nil {
+		stages = append(stages, "fingerprint")
 	}
-
-	resp, err := d.client.Post(
-		d.modelEndpoint+"/detect",
-		"application/json",
-		bytes.NewBuffer(reqBody),
-	)
-	if err != nil {
-		return nil, err
+	switch stage {
+	case "preclassifier":
+		stages = append(stages, "preclassifier")
+	case "sampling":
+		if d.preclassifier.Enabled {
+			stages = append(stages, "preclassifier")
+		}
+		stages = append(stages, "sampling")
+	case "model":
+		if d.preclassifier.Enabled {
+			stages = append(stages, "preclassifier")
+		}
+		stages = append(stages, "model")
+	case "fallback":
+		stages = append(stages, "fallback")
+	case "degraded":
+		stages = append(stages, "degraded")
 	}
-	defer resp.Body.Close()
+	return stages
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status: %s", resp.Status)
+// modelConfidence returns the model's benign confidence when the model
+// stage produced the verdict, zero otherwise.
+func modelConfidence(stage string, result *guard.ThreatResult) float64 {
+	if stage == "model" {
+		return result.Confidence
 	}
+	return 0
+}
 
-	var result guard.ThreatResult
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
+// appendLabel adds a label unless the type is already present.
+func appendLabel(labels []guard.Label, label guard.Label) []guard.Label {
+	for _, existing := range labels {
+		if existing.ThreatType == label.ThreatType {
+			return labels
+		}
 	}
+	return append(labels, label)
+}
 
-	return &result, nil
+type apiRequest struct {
+	Text string `json:"text"`
 }
@@ -0,0 +1,86 @@
+package detector
+
+import (
+	"sync"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Differential mode runs live traffic through both the regex-only view and
+// the full hybrid pipeline and records every disagreement. The collected
+// dataset quantifies what the model stage buys us and where regexes are
+// already sufficient.
+
+// Disagreement is one input where the two pipelines reached different
+// verdicts, with both traces.
+type Disagreement struct {
+	Input        string              `json:"input"`
+	RegexResult  *guard.ThreatResult `json:"regex_result"`
+	HybridResult *guard.ThreatResult `json:"hybrid_result"`
+	Timestamp    time.Time           `json:"timestamp"`
+}
+
+type differentialRecorder struct {
+	mu            sync.Mutex
+	maxRecords    int
+	disagreements []Disagreement
+	compared      uint64
+	disagreed     uint64
+}
+
+// EnableDifferential turns on disagreement recording, keeping at most
+// maxRecords full traces (older records are dropped first).
+func (d *EnhancedDetector) EnableDifferential(maxRecords int) {
+	if maxRecords <= 0 {
+		maxRecords = 1000
+	}
+	d.differential = &differentialRecorder{maxRecords: maxRecords}
+}
+
+// Disagreements returns the recorded disagreement traces.
+func (d *EnhancedDetector) Disagreements() []Disagreement {
+	if d.differential == nil {
+		return nil
+	}
+	d.differential.mu.Lock()
+	defer d.differential.mu.Unlock()
+	records := make([]Disagreement, len(d.differential.disagreements))
+	copy(records, d.differential.disagreements)
+	return records
+}
+
+// DifferentialStats reports how many inputs were compared and how many
+// produced different verdicts.
+func (d *EnhancedDetector) DifferentialStats() (compared, disagreed uint64) {
+	if d.differential == nil {
+		return 0, 0
+	}
+	d.differential.mu.Lock()
+	defer d.differential.mu.Unlock()
+	return d.differential.compared, d.differential.disagreed
+}
+
+// record compares the regex-stage verdict with the hybrid verdict for one
+// input and stores a trace when they differ.
+func (r *differentialRecorder) record(input string, regexResult, hybridResult *guard.ThreatResult) {
+	regexThreat := regexResult != nil && regexResult.IsThreat
+	hybridThreat := hybridResult != nil && hybridResult.IsThreat
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.compared++
+	if regexThreat == hybridThreat {
+		return
+	}
+	r.disagreed++
+	r.disagreements = append(r.disagreements, Disagreement{
+		Input:        input,
+		RegexResult:  regexResult,
+		HybridResult: hybridResult,
+		Timestamp:    time.Now(),
+	})
+	if len(r.disagreements) > r.maxRecords {
+		r.disagreements = r.disagreements[len(r.disagreements)-r.maxRecords:]
+	}
+}
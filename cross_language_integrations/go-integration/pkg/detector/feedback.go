@@ -0,0 +1,146 @@
+package detector
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/marcusjohansson/trust-go/pkg/audit"
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// False-positive feedback: when review confirms that an input keeps
+// tripping a pattern it shouldn't, the verdict is reported back and the
+// (input hash, threat type) pair lands in a persistent suppression store.
+// Reported pairs are first down-weighted and, once the report count reaches
+// the review threshold, auto-allowed — replacing the ad-hoc allowlists
+// integrators kept outside the package.
+
+type suppressionKey struct {
+	inputHash  string
+	threatType string
+}
+
+type suppressionEntry struct {
+	InputHash  string `json:"input_hash"`
+	ThreatType string `json:"threat_type"`
+	Reports    int    `json:"reports"`
+}
+
+// SuppressionStore holds reviewed false positives, persisted as JSON.
+type SuppressionStore struct {
+	path      string
+	threshold int
+
+	mu      sync.RWMutex
+	entries map[suppressionKey]*suppressionEntry
+}
+
+// EnableFeedback attaches a suppression store persisted at path, loading
+// any existing entries. Pairs reported at least threshold times (0 uses 1)
+// are suppressed to benign; pairs below the threshold are down-weighted.
+func (d *EnhancedDetector) EnableFeedback(path string, threshold int) (*SuppressionStore, error) {
+	if threshold <= 0 {
+		threshold = 1
+	}
+	store := &SuppressionStore{
+		path:      path,
+		threshold: threshold,
+		entries:   map[suppressionKey]*suppressionEntry{},
+	}
+	if err := store.load(); err != nil {
+		return nil, err
+	}
+	d.feedback = store
+	return store, nil
+}
+
+// ReportFalsePositive records that the verdict for inputHash (as found in
+// audit records and published events) was a false positive for threatType.
+func (d *EnhancedDetector) ReportFalsePositive(inputHash, threatType string) error {
+	if d.feedback == nil {
+		return fmt.Errorf("feedback store not enabled; call EnableFeedback first")
+	}
+	return d.feedback.report(inputHash, threatType)
+}
+
+func (s *SuppressionStore) report(inputHash, threatType string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := suppressionKey{inputHash: inputHash, threatType: threatType}
+	entry, ok := s.entries[key]
+	if !ok {
+		entry = &suppressionEntry{InputHash: inputHash, ThreatType: threatType}
+		s.entries[key] = entry
+	}
+	entry.Reports++
+	return s.save()
+}
+
+// Reports returns how many false-positive reports a pair has accumulated.
+func (s *SuppressionStore) Reports(inputHash, threatType string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if entry, ok := s.entries[suppressionKey{inputHash: inputHash, threatType: threatType}]; ok {
+		return entry.Reports
+	}
+	return 0
+}
+
+// apply rewrites a threat verdict according to the store: suppressed pairs
+// become benign, reported-but-unconfirmed pairs keep the verdict at half
+// confidence.
+func (s *SuppressionStore) apply(text string, result *guard.ThreatResult) *guard.ThreatResult {
+	if result == nil || !result.IsThreat || result.Honeytoken {
+		return result
+	}
+	reports := s.Reports(audit.HashInput(text), result.ThreatType)
+	if reports == 0 {
+		return result
+	}
+	if reports >= s.threshold {
+		return &guard.ThreatResult{
+			IsThreat:   false,
+			ThreatType: "benign",
+			Confidence: 0.99,
+			Reasoning:  fmt.Sprintf("Suppressed: %s verdict reviewed as false positive (%d reports)", result.ThreatType, reports),
+		}
+	}
+	downweighted := *result
+	downweighted.Confidence = result.Confidence / 2
+	downweighted.Reasoning += fmt.Sprintf(" (down-weighted: %d false-positive reports pending review)", reports)
+	return &downweighted
+}
+
+func (s *SuppressionStore) load() error {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var entries []suppressionEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("corrupt suppression store %s: %w", s.path, err)
+	}
+	for i := range entries {
+		entry := entries[i]
+		s.entries[suppressionKey{inputHash: entry.InputHash, threatType: entry.ThreatType}] = &entry
+	}
+	return nil
+}
+
+// save is called with s.mu held.
+func (s *SuppressionStore) save() error {
+	entries := make([]suppressionEntry, 0, len(s.entries))
+	for _, entry := range s.entries {
+		entries = append(entries, *entry)
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o600)
+}
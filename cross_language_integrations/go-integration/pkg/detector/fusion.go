@@ -0,0 +1,144 @@
+package detector
+
+import (
+	"fmt"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// FusionStrategy selects how the regex and model verdicts are combined when
+// both stages ran.
+type FusionStrategy string
+
+const (
+	// FusionModelPriority trusts the model verdict when both stages flag the
+	// input; a model "benign" downgrades a regex match to DisagreementConfidence.
+	// This is the historical behavior and the default.
+	FusionModelPriority FusionStrategy = "model_priority"
+	// FusionRegexPriority keeps the regex verdict as primary whenever regex
+	// matched, treating the model as advisory.
+	FusionRegexPriority FusionStrategy = "regex_priority"
+	// FusionMax keeps whichever verdict has the higher confidence.
+	FusionMax FusionStrategy = "max"
+	// FusionWeighted averages the two confidences using RegexWeight and
+	// ModelWeight; a model "benign" counts as zero model confidence.
+	FusionWeighted FusionStrategy = "weighted"
+)
+
+// FusionConfig tunes the precision/recall tradeoff of the fusion stage per
+// deployment.
+type FusionConfig struct {
+	Strategy FusionStrategy `json:"strategy"`
+	// RegexWeight and ModelWeight are only used by FusionWeighted.
+	RegexWeight float64 `json:"regex_weight,omitempty"`
+	ModelWeight float64 `json:"model_weight,omitempty"`
+	// DisagreementConfidence is the confidence assigned under
+	// FusionModelPriority when regex matched but the model said benign.
+	DisagreementConfidence float64 `json:"disagreement_confidence,omitempty"`
+}
+
+// DefaultFusionConfig reproduces the pipeline's historical fusion behavior.
+func DefaultFusionConfig() FusionConfig {
+	return FusionConfig{
+		Strategy:               FusionModelPriority,
+		RegexWeight:            0.5,
+		ModelWeight:            0.5,
+		DisagreementConfidence: 0.5,
+	}
+}
+
+// SetFusion replaces the fusion configuration. Unset fields fall back to
+// their defaults.
+func (d *EnhancedDetector) SetFusion(config FusionConfig) {
+	defaults := DefaultFusionConfig()
+	if config.Strategy == "" {
+		config.Strategy = defaults.Strategy
+	}
+	if config.RegexWeight <= 0 {
+		config.RegexWeight = defaults.RegexWeight
+	}
+	if config.ModelWeight <= 0 {
+		config.ModelWeight = defaults.ModelWeight
+	}
+	if config.DisagreementConfidence <= 0 {
+		config.DisagreementConfidence = defaults.DisagreementConfidence
+	}
+	d.fusion = config
+}
+
+// fuse combines a regex threat verdict with the model verdict according to
+// the configured strategy. regexResult is always a threat here; apiResult
+// may be benign.
+func (c FusionConfig) fuse(regexResult, apiResult *guard.ThreatResult) *guard.ThreatResult {
+	switch c.Strategy {
+	case FusionRegexPriority:
+		fused := *regexResult
+		if apiResult.IsThreat && apiResult.ThreatType != fused.ThreatType {
+			fused.Secondary = appendLabel(fused.Secondary, guard.Label{
+				ThreatType: apiResult.ThreatType,
+				Confidence: apiResult.Confidence,
+			})
+		}
+		return &fused
+
+	case FusionMax:
+		if apiResult.IsThreat && apiResult.Confidence >= regexResult.Confidence {
+			return foldLabels(apiResult, regexResult)
+		}
+		fused := *regexResult
+		if apiResult.IsThreat && apiResult.ThreatType != fused.ThreatType {
+			fused.Secondary = appendLabel(fused.Secondary, guard.Label{
+				ThreatType: apiResult.ThreatType,
+				Confidence: apiResult.Confidence,
+			})
+		}
+		return &fused
+
+	case FusionWeighted:
+		modelConfidence := 0.0
+		if apiResult.IsThreat {
+			modelConfidence = apiResult.Confidence
+		}
+		combined := (c.RegexWeight*regexResult.Confidence + c.ModelWeight*modelConfidence) /
+			(c.RegexWeight + c.ModelWeight)
+		var fused *guard.ThreatResult
+		if apiResult.IsThreat {
+			fused = foldLabels(apiResult, regexResult)
+		} else {
+			copied := *regexResult
+			fused = &copied
+		}
+		fused.Confidence = combined
+		return fused
+
+	default: // FusionModelPriority
+		if !apiResult.IsThreat {
+			return &guard.ThreatResult{
+				IsThreat:   true,
+				ThreatType: regexResult.ThreatType,
+				Confidence: c.DisagreementConfidence,
+				Reasoning:  fmt.Sprintf("Regex match '%s' (Model disagreed)", regexResult.Reasoning),
+				Severity:   regexResult.Severity,
+				Secondary:  regexResult.Secondary,
+			}
+		}
+		return foldLabels(apiResult, regexResult)
+	}
+}
+
+// foldLabels keeps apiResult as the primary verdict and folds the regex
+// categories into its secondary labels.
+func foldLabels(apiResult, regexResult *guard.ThreatResult) *guard.ThreatResult {
+	if apiResult.ThreatType != regexResult.ThreatType {
+		apiResult.Secondary = appendLabel(apiResult.Secondary, guard.Label{
+			ThreatType: regexResult.ThreatType,
+			Confidence: regexResult.Confidence,
+		})
+	}
+	for _, label := range regexResult.Secondary {
+		if label.ThreatType != apiResult.ThreatType {
+			apiResult.Secondary = appendLabel(apiResult.Secondary, label)
+		}
+	}
+	return apiResult
+}
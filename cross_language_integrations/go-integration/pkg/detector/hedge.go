@@ -0,0 +1,76 @@
+package detector
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+	"github.com/marcusjohansson/trust-go/pkg/guardmetrics"
+)
+
+// Hedged requests cut tail latency caused by occasional slow inference
+// nodes: if the first model call has not answered within the hedging delay,
+// a second is launched and whichever answers first wins. Pair with
+// BackendPool so the hedge lands on a different replica.
+
+type hedgedBackend struct {
+	inner ModelBackend
+	delay time.Duration
+}
+
+// WrapHedged wraps a model backend with request hedging at the given delay
+// (0 uses 200ms).
+func WrapHedged(backend ModelBackend, delay time.Duration) ModelBackend {
+	if delay <= 0 {
+		delay = 200 * time.Millisecond
+	}
+	return &hedgedBackend{inner: backend, delay: delay}
+}
+
+// EnableHedging wraps the detector's model backend with request hedging.
+func (d *EnhancedDetector) EnableHedging(delay time.Duration) {
+	d.backend = WrapHedged(d.backend, delay)
+}
+
+// Classify implements ModelBackend.
+func (b *hedgedBackend) Classify(ctx context.Context, text string) (*guard.ThreatResult, error) {
+	type outcome struct {
+		result *guard.ThreatResult
+		err    error
+	}
+	outcomes := make(chan outcome, 2)
+	launch := func() {
+		go func() {
+			result, err := b.inner.Classify(ctx, text)
+			outcomes <- outcome{result, err}
+		}()
+	}
+
+	launch()
+	launched := 1
+	timer := time.NewTimer(b.delay)
+	defer timer.Stop()
+
+	var firstErr error
+	for received := 0; received < launched; {
+		select {
+		case <-timer.C:
+			if launched == 1 {
+				guardmetrics.HedgedRequestsTotal.Inc()
+				launch()
+				launched = 2
+			}
+		case out := <-outcomes:
+			received++
+			if out.err == nil {
+				return out.result, nil
+			}
+			if firstErr == nil {
+				firstErr = out.err
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return nil, firstErr
+}
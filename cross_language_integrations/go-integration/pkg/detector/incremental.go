@@ -0,0 +1,87 @@
+package detector
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// IncrementalDetector scans progressively longer versions of the same
+// input — a user typing, an IME composing, speech being transcribed — and
+// surfaces warnings before submission. Each Update only rescans the unseen
+// tail (plus an overlap window for matches spanning the boundary) with the
+// cheap regex stages, so calling it per keystroke is affordable; the full
+// hybrid pipeline runs once, on Submit.
+type IncrementalDetector struct {
+	det *EnhancedDetector
+	// overlap is how many trailing bytes of already-scanned text are
+	// rescanned with each extension.
+	overlap int
+
+	mu      sync.Mutex
+	seen    string
+	warning *guard.ThreatResult
+}
+
+// incrementalOverlap covers patterns that straddle an update boundary.
+const incrementalOverlap = 256
+
+// NewIncremental creates an incremental scanner in front of the detector.
+// One IncrementalDetector tracks one composition; create a new one per
+// input field or utterance.
+func NewIncremental(det *EnhancedDetector) *IncrementalDetector {
+	return &IncrementalDetector{det: det, overlap: incrementalOverlap}
+}
+
+// Update scans the current state of the input and returns the strongest
+// warning seen so far (nil while the composition looks clean). Inputs that
+// extend the previous update only rescan the new tail; an edit anywhere
+// else rescans from scratch.
+func (i *IncrementalDetector) Update(text string) *guard.ThreatResult {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	scanFrom := 0
+	if strings.HasPrefix(text, i.seen) {
+		scanFrom = len(i.seen) - i.overlap
+		if scanFrom < 0 {
+			scanFrom = 0
+		}
+	} else {
+		// The user edited earlier text; prior warnings may no longer apply.
+		i.warning = nil
+	}
+	i.seen = text
+
+	result := guard.CheckRegex(text[scanFrom:])
+	if result == nil {
+		result = guard.CheckEncoded(text[scanFrom:])
+	}
+	if result != nil && (i.warning == nil || result.Confidence > i.warning.Confidence) {
+		i.warning = result
+	}
+	return i.warning
+}
+
+// Warning returns the strongest warning seen so far without rescanning.
+func (i *IncrementalDetector) Warning() *guard.ThreatResult {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.warning
+}
+
+// Reset clears the composition state for a new input.
+func (i *IncrementalDetector) Reset() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.seen = ""
+	i.warning = nil
+}
+
+// Submit runs the full hybrid pipeline on the final input. The incremental
+// warnings are advisory; this is the verdict that counts.
+func (i *IncrementalDetector) Submit(ctx context.Context, text string) (*guard.ThreatResult, error) {
+	return i.det.DetectContext(ctx, text)
+}
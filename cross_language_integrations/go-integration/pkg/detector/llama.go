@@ -0,0 +1,54 @@
+//go:build llama
+
+package detector
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	llama "github.com/go-skynet/go-llama.cpp"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// LlamaBackend performs classification in-process with a GGUF guard model
+// loaded through llama.cpp, making the Go integration fully self-contained.
+// It is behind the "llama" build tag because the binding needs the
+// llama.cpp C++ library at build time:
+//
+//	go build -tags llama ./...
+type LlamaBackend struct {
+	// Predict calls are serialized; llama.cpp contexts are not reentrant.
+	mu    sync.Mutex
+	model *llama.LLama
+}
+
+// NewLlamaBackend loads a GGUF model from modelPath. contextSize 0 uses 2048.
+func NewLlamaBackend(modelPath string, contextSize int) (*LlamaBackend, error) {
+	if contextSize <= 0 {
+		contextSize = 2048
+	}
+	model, err := llama.New(modelPath, llama.SetContext(contextSize))
+	if err != nil {
+		return nil, fmt.Errorf("loading GGUF model: %w", err)
+	}
+	return &LlamaBackend{model: model}, nil
+}
+
+// Classify implements ModelBackend.
+func (b *LlamaBackend) Classify(_ context.Context, text string) (*guard.ThreatResult, error) {
+	b.mu.Lock()
+	reply, err := b.model.Predict(classificationPrompt(text),
+		llama.SetTokens(256), llama.SetTemperature(0.1))
+	b.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("llama.cpp inference: %w", err)
+	}
+	return parseClassification(reply)
+}
+
+// Close frees the loaded model.
+func (b *LlamaBackend) Close() {
+	b.model.Free()
+}
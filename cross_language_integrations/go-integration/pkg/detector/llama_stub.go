@@ -0,0 +1,17 @@
+//go:build !llama
+
+package detector
+
+import (
+	"fmt"
+)
+
+// NewLlamaBackendUnavailable is returned by NewLlamaBackend in builds
+// without the "llama" tag.
+var NewLlamaBackendUnavailable = fmt.Errorf("llama.cpp backend requires building with -tags llama")
+
+// NewLlamaBackend is a stub; the real implementation lives behind the
+// "llama" build tag (see llama.go).
+func NewLlamaBackend(modelPath string, contextSize int) (ModelBackend, error) {
+	return nil, NewLlamaBackendUnavailable
+}
@@ -0,0 +1,77 @@
+package detector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// OllamaBackend classifies inputs via a local Ollama server (POST /api/generate).
+type OllamaBackend struct {
+	BaseURL string // e.g. http://localhost:11434
+	Model   string // e.g. llama3.2
+	Client  *http.Client
+}
+
+// NewOllamaBackend creates a backend using a local Ollama server.
+func NewOllamaBackend(baseURL, model string) *OllamaBackend {
+	return &OllamaBackend{
+		BaseURL: baseURL,
+		Model:   model,
+		Client: &http.Client{
+			Timeout: 60 * time.Second,
+		},
+	}
+}
+
+type ollamaRequest struct {
+	Model  string `json:"model"`
+	Prompt string `json:"prompt"`
+	Stream bool   `json:"stream"`
+	Format string `json:"format,omitempty"`
+}
+
+type ollamaResponse struct {
+	Response string `json:"response"`
+}
+
+// Classify asks the local model to classify the text and parses the JSON reply.
+func (b *OllamaBackend) Classify(ctx context.Context, text string) (*guard.ThreatResult, error) {
+	reqBody, err := json.Marshal(ollamaRequest{
+		Model:  b.Model,
+		Prompt: classificationPrompt(text),
+		Stream: false,
+		Format: "json",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.BaseURL+"/api/generate", bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Ollama API returned status: %s", resp.Status)
+	}
+
+	var apiResp ollamaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, err
+	}
+
+	return parseClassification(apiResp.Response)
+}
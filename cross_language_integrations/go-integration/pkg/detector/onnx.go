@@ -0,0 +1,225 @@
+package detector
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"sync"
+
+	ort "github.com/yalue/onnxruntime_go"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// ONNXBackend runs a small quantized transformer classifier locally through
+// ONNX Runtime, removing the network dependency of the model stage for edge
+// deployments. It expects a BERT-style model (input_ids + attention_mask in,
+// one logit per threat label out), a WordPiece vocab.txt, and a JSON array
+// of label names matching the output order.
+type ONNXBackend struct {
+	mu      sync.Mutex
+	session *ort.DynamicAdvancedSession
+	vocab   map[string]int64
+	labels  []string
+	maxLen  int
+}
+
+const onnxMaxSequence = 256
+
+var ortInitOnce sync.Once
+
+// NewONNXBackend loads the classifier. libraryPath optionally points at the
+// onnxruntime shared library; empty uses the system default search path.
+func NewONNXBackend(modelPath, vocabPath, labelsPath, libraryPath string) (*ONNXBackend, error) {
+	var initErr error
+	ortInitOnce.Do(func() {
+		if libraryPath != "" {
+			ort.SetSharedLibraryPath(libraryPath)
+		}
+		initErr = ort.InitializeEnvironment()
+	})
+	if initErr != nil {
+		return nil, fmt.Errorf("initializing onnxruntime: %w", initErr)
+	}
+
+	vocab, err := loadVocab(vocabPath)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := loadLabels(labelsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := ort.NewDynamicAdvancedSession(modelPath,
+		[]string{"input_ids", "attention_mask"}, []string{"logits"}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("loading ONNX model: %w", err)
+	}
+	return &ONNXBackend{
+		session: session,
+		vocab:   vocab,
+		labels:  labels,
+		maxLen:  onnxMaxSequence,
+	}, nil
+}
+
+// Classify implements ModelBackend.
+func (b *ONNXBackend) Classify(_ context.Context, text string) (*guard.ThreatResult, error) {
+	ids := b.tokenize(text)
+	mask := make([]int64, len(ids))
+	for i := range mask {
+		mask[i] = 1
+	}
+
+	shape := ort.NewShape(1, int64(len(ids)))
+	inputTensor, err := ort.NewTensor(shape, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer inputTensor.Destroy()
+	maskTensor, err := ort.NewTensor(shape, mask)
+	if err != nil {
+		return nil, err
+	}
+	defer maskTensor.Destroy()
+
+	outputs := []ort.Value{nil}
+	b.mu.Lock()
+	err = b.session.Run([]ort.Value{inputTensor, maskTensor}, outputs)
+	b.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("ONNX inference: %w", err)
+	}
+	logitsTensor, ok := outputs[0].(*ort.Tensor[float32])
+	if !ok {
+		return nil, fmt.Errorf("unexpected ONNX output type %T", outputs[0])
+	}
+	defer logitsTensor.Destroy()
+
+	logits := logitsTensor.GetData()
+	if len(logits) != len(b.labels) {
+		return nil, fmt.Errorf("model emitted %d logits for %d labels", len(logits), len(b.labels))
+	}
+
+	probabilities := softmax(logits)
+	best := 0
+	for i := range probabilities {
+		if probabilities[i] > probabilities[best] {
+			best = i
+		}
+	}
+	label := b.labels[best]
+	return &guard.ThreatResult{
+		IsThreat:   label != "benign",
+		ThreatType: label,
+		Confidence: float64(probabilities[best]),
+		Reasoning:  "Local ONNX classifier",
+		Severity:   guard.SeverityForType(label),
+	}, nil
+}
+
+// Close releases the ONNX session.
+func (b *ONNXBackend) Close() error {
+	return b.session.Destroy()
+}
+
+// tokenize is a greedy WordPiece tokenizer over the loaded vocab, producing
+// [CLS] tokens... [SEP] truncated to the max sequence length.
+func (b *ONNXBackend) tokenize(text string) []int64 {
+	ids := []int64{b.tokenID("[CLS]")}
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		ids = append(ids, b.wordPieces(word)...)
+		if len(ids) >= b.maxLen-1 {
+			ids = ids[:b.maxLen-1]
+			break
+		}
+	}
+	return append(ids, b.tokenID("[SEP]"))
+}
+
+func (b *ONNXBackend) wordPieces(word string) []int64 {
+	var pieces []int64
+	start := 0
+	for start < len(word) {
+		end := len(word)
+		found := false
+		for end > start {
+			piece := word[start:end]
+			if start > 0 {
+				piece = "##" + piece
+			}
+			if id, ok := b.vocab[piece]; ok {
+				pieces = append(pieces, id)
+				start = end
+				found = true
+				break
+			}
+			end--
+		}
+		if !found {
+			return []int64{b.tokenID("[UNK]")}
+		}
+	}
+	return pieces
+}
+
+func (b *ONNXBackend) tokenID(token string) int64 {
+	return b.vocab[token]
+}
+
+func loadVocab(path string) (map[string]int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading vocab: %w", err)
+	}
+	defer file.Close()
+
+	vocab := map[string]int64{}
+	scanner := bufio.NewScanner(file)
+	for id := int64(0); scanner.Scan(); id++ {
+		vocab[scanner.Text()] = id
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return vocab, nil
+}
+
+func loadLabels(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading labels: %w", err)
+	}
+	var labels []string
+	if err := json.Unmarshal(data, &labels); err != nil {
+		return nil, fmt.Errorf("parsing labels: %w", err)
+	}
+	if len(labels) == 0 {
+		return nil, fmt.Errorf("labels file %s is empty", path)
+	}
+	return labels, nil
+}
+
+func softmax(logits []float32) []float32 {
+	max := logits[0]
+	for _, v := range logits[1:] {
+		if v > max {
+			max = v
+		}
+	}
+	probabilities := make([]float32, len(logits))
+	var sum float32
+	for i, v := range logits {
+		probabilities[i] = float32(math.Exp(float64(v - max)))
+		sum += probabilities[i]
+	}
+	for i := range probabilities {
+		probabilities[i] /= sum
+	}
+	return probabilities
+}
@@ -0,0 +1,94 @@
+package detector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// OpenAIBackend classifies inputs via an OpenAI-compatible chat completions
+// API. It also works with self-hosted servers (vLLM, llama.cpp, LM Studio)
+// that expose the same endpoint.
+type OpenAIBackend struct {
+	BaseURL string // e.g. https://api.openai.com/v1
+	APIKey  string
+	Model   string // e.g. gpt-4o-mini
+	Client  *http.Client
+}
+
+// NewOpenAIBackend creates a backend using the OpenAI chat completions API.
+func NewOpenAIBackend(baseURL, apiKey, model string) *OpenAIBackend {
+	return &OpenAIBackend{
+		BaseURL: baseURL,
+		APIKey:  apiKey,
+		Model:   model,
+		Client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIRequest struct {
+	Model       string          `json:"model"`
+	Messages    []openAIMessage `json:"messages"`
+	Temperature float64         `json:"temperature"`
+}
+
+type openAIResponse struct {
+	Choices []struct {
+		Message openAIMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// Classify asks the chat model to classify the text and parses the JSON reply.
+func (b *OpenAIBackend) Classify(ctx context.Context, text string) (*guard.ThreatResult, error) {
+	reqBody, err := json.Marshal(openAIRequest{
+		Model: b.Model,
+		Messages: []openAIMessage{
+			{Role: "user", Content: classificationPrompt(text)},
+		},
+		Temperature: 0,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.BaseURL+"/chat/completions", bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if b.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+b.APIKey)
+	}
+
+	resp, err := b.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OpenAI API returned status: %s", resp.Status)
+	}
+
+	var apiResp openAIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, err
+	}
+	if len(apiResp.Choices) == 0 {
+		return nil, fmt.Errorf("OpenAI API returned no choices")
+	}
+
+	return parseClassification(apiResp.Choices[0].Message.Content)
+}
@@ -0,0 +1,213 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// BackendPool spreads model calls over several inference replicas with
+// health checking and automatic failover, so deployments with multiple
+// endpoints do not need a separate load balancer just for the guard.
+
+// PoolStrategy selects how the pool picks among healthy endpoints.
+type PoolStrategy string
+
+const (
+	// PoolRoundRobin rotates through healthy endpoints.
+	PoolRoundRobin PoolStrategy = "round_robin"
+	// PoolLeastLatency prefers the endpoint with the lowest observed
+	// classification latency (exponential moving average).
+	PoolLeastLatency PoolStrategy = "least_latency"
+)
+
+// unhealthyCooldown is how long a failed endpoint sits out before being
+// retried by passive health checking.
+const unhealthyCooldown = 30 * time.Second
+
+type poolEndpoint struct {
+	endpoint string
+	backend  ModelBackend
+
+	mu          sync.Mutex
+	healthy     bool
+	lastFailure time.Time
+	emaLatency  time.Duration
+}
+
+func (e *poolEndpoint) available() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.healthy || time.Since(e.lastFailure) >= unhealthyCooldown
+}
+
+func (e *poolEndpoint) recordSuccess(latency time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.healthy = true
+	if e.emaLatency == 0 {
+		e.emaLatency = latency
+	} else {
+		e.emaLatency = (e.emaLatency*4 + latency) / 5
+	}
+}
+
+func (e *poolEndpoint) recordFailure() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.healthy = false
+	e.lastFailure = time.Now()
+}
+
+// BackendPool is a ModelBackend fanning out to several endpoints.
+type BackendPool struct {
+	strategy  PoolStrategy
+	endpoints []*poolEndpoint
+
+	mu   sync.Mutex
+	next int
+}
+
+// NewBackendPool builds a pool of HTTP model backends.
+func NewBackendPool(strategy PoolStrategy, endpoints ...string) *BackendPool {
+	pool := &BackendPool{strategy: strategy}
+	for _, endpoint := range endpoints {
+		pool.endpoints = append(pool.endpoints, &poolEndpoint{
+			endpoint: endpoint,
+			backend:  NewHTTPBackend(endpoint),
+			healthy:  true,
+		})
+	}
+	return pool
+}
+
+// NewEnhancedMulti creates a detector backed by several model endpoints
+// with round-robin selection and automatic failover.
+func NewEnhancedMulti(configPath, regexPath string, modelEndpoints []string) (*EnhancedDetector, error) {
+	if len(modelEndpoints) == 0 {
+		return nil, fmt.Errorf("at least one model endpoint is required")
+	}
+	return NewEnhancedWithBackend(configPath, regexPath,
+		NewBackendPool(PoolRoundRobin, modelEndpoints...))
+}
+
+// Classify implements ModelBackend: it tries endpoints in strategy order,
+// failing over until one succeeds.
+func (p *BackendPool) Classify(ctx context.Context, text string) (*guard.ThreatResult, error) {
+	candidates := p.ordered()
+	var lastErr error
+	for _, endpoint := range candidates {
+		start := time.Now()
+		result, err := endpoint.backend.Classify(ctx, text)
+		if err == nil {
+			endpoint.recordSuccess(time.Since(start))
+			return result, nil
+		}
+		endpoint.recordFailure()
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no model endpoints available")
+	}
+	return nil, lastErr
+}
+
+// ordered returns endpoints in the order to try: strategy choice over the
+// available ones first, sidelined endpoints last as a final resort.
+func (p *BackendPool) ordered() []*poolEndpoint {
+	var available, sidelined []*poolEndpoint
+	for _, endpoint := range p.endpoints {
+		if endpoint.available() {
+			available = append(available, endpoint)
+		} else {
+			sidelined = append(sidelined, endpoint)
+		}
+	}
+
+	switch p.strategy {
+	case PoolLeastLatency:
+		for i := 1; i < len(available); i++ {
+			for j := i; j > 0; j-- {
+				a, b := available[j-1], available[j]
+				a.mu.Lock()
+				latencyA := a.emaLatency
+				a.mu.Unlock()
+				b.mu.Lock()
+				latencyB := b.emaLatency
+				b.mu.Unlock()
+				if latencyB < latencyA {
+					available[j-1], available[j] = b, a
+				}
+			}
+		}
+	default: // round robin
+		if len(available) > 1 {
+			p.mu.Lock()
+			offset := p.next % len(available)
+			p.next++
+			p.mu.Unlock()
+			available = append(available[offset:], available[:offset]...)
+		}
+	}
+	return append(available, sidelined...)
+}
+
+// StartHealthChecks actively probes every endpoint's /healthz at the given
+// interval until the context is canceled, complementing the passive checks
+// done on call failures.
+func (p *BackendPool) StartHealthChecks(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = 15 * time.Second
+	}
+	client := &http.Client{Timeout: 5 * time.Second}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				for _, endpoint := range p.endpoints {
+					p.probe(client, endpoint)
+				}
+			}
+		}
+	}()
+}
+
+func (p *BackendPool) probe(client *http.Client, endpoint *poolEndpoint) {
+	resp, err := client.Get(endpoint.endpoint + "/healthz")
+	if err != nil {
+		endpoint.recordFailure()
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		endpoint.recordFailure()
+		return
+	}
+	endpoint.mu.Lock()
+	endpoint.healthy = true
+	endpoint.mu.Unlock()
+}
+
+// Healthy returns the endpoints currently considered healthy.
+func (p *BackendPool) Healthy() []string {
+	var healthy []string
+	for _, endpoint := range p.endpoints {
+		endpoint.mu.Lock()
+		if endpoint.healthy {
+			healthy = append(healthy, endpoint.endpoint)
+		}
+		endpoint.mu.Unlock()
+	}
+	return healthy
+}
@@ -0,0 +1,100 @@
+package detector
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+	"github.com/marcusjohansson/trust-go/pkg/guardmetrics"
+)
+
+// A large fraction of traffic is trivially benign ("thanks!", "yes",
+// emoji-only). The pre-classifier runs after the regex stage found nothing
+// and uses cheap heuristics (length, character classes, keyword absence) to
+// skip the model call when its certainty clears a configurable bound.
+
+// PreClassifierConfig controls the benign pre-classifier.
+type PreClassifierConfig struct {
+	Enabled bool `json:"enabled"`
+	// MaxLength is the longest input (in runes) eligible for skipping;
+	// 0 uses 80.
+	MaxLength int `json:"max_length,omitempty"`
+	// Certainty is the minimum benign certainty required to skip the model;
+	// 0 uses 0.9.
+	Certainty float64 `json:"certainty,omitempty"`
+}
+
+// EnablePreClassifier configures the benign pre-classifier.
+func (d *EnhancedDetector) EnablePreClassifier(config PreClassifierConfig) {
+	if config.MaxLength <= 0 {
+		config.MaxLength = 80
+	}
+	if config.Certainty <= 0 {
+		config.Certainty = 0.9
+	}
+	d.preclassifier = config
+}
+
+// suspectWords are cheap lexical signals that an input deserves the model
+// stage even though no regex pattern matched.
+var suspectWords = []string{
+	"ignore", "instruction", "prompt", "system", "override", "bypass",
+	"password", "admin", "root", "token", "secret", "execute", "eval",
+	"pretend", "roleplay", "jailbreak", "sudo", "disregard",
+}
+
+// benignCertainty scores how certain the heuristics are that text is
+// benign, 0-1.
+func benignCertainty(text string, maxLength int) float64 {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return 1.0
+	}
+	runes := []rune(trimmed)
+	if len(runes) > maxLength {
+		return 0
+	}
+
+	lower := strings.ToLower(trimmed)
+	for _, word := range suspectWords {
+		if strings.Contains(lower, word) {
+			return 0
+		}
+	}
+
+	// Shorter inputs carry less room for an attack.
+	certainty := 1.0 - 0.3*float64(len(runes))/float64(maxLength)
+
+	// Code-ish or control characters warrant the model stage.
+	for _, r := range runes {
+		if unicode.IsControl(r) {
+			return 0
+		}
+		if strings.ContainsRune("{}<>$`\\|;", r) {
+			certainty -= 0.3
+		}
+	}
+	if certainty < 0 {
+		certainty = 0
+	}
+	return certainty
+}
+
+// preclassify returns a benign verdict when the pre-classifier is enabled
+// and certain enough, or nil to continue to the model stage.
+func (d *EnhancedDetector) preclassify(text string) *guard.ThreatResult {
+	if !d.preclassifier.Enabled {
+		return nil
+	}
+	certainty := benignCertainty(text, d.preclassifier.MaxLength)
+	if certainty < d.preclassifier.Certainty {
+		return nil
+	}
+	guardmetrics.PreClassifierSkipsTotal.Inc()
+	return &guard.ThreatResult{
+		IsThreat:   false,
+		ThreatType: "benign",
+		Confidence: certainty,
+		Reasoning:  "Pre-classifier: trivially benign input, model stage skipped",
+	}
+}
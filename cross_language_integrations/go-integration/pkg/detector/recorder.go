@@ -0,0 +1,148 @@
+package detector
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+	"github.com/marcusjohansson/trust-go/pkg/pii"
+)
+
+// Record/replay debugging for the model stage: every model call is captured
+// (input redacted of PII) under a decision ID, and a recorded interaction
+// can be re-sent against a different backend to see how a classification
+// would change.
+
+// Interaction is one recorded model-stage request/response pair.
+type Interaction struct {
+	DecisionID string    `json:"decision_id"`
+	Timestamp  time.Time `json:"timestamp"`
+	// Text is the model input after PII redaction.
+	Text   string              `json:"text"`
+	Result *guard.ThreatResult `json:"result,omitempty"`
+	Error  string              `json:"error,omitempty"`
+}
+
+// InteractionStore keeps recorded interactions, newest-last, bounded.
+type InteractionStore struct {
+	mu   sync.Mutex
+	byID map[string]Interaction
+	ids  []string
+	max  int
+}
+
+// EnableRecording wraps the model backend so every call is recorded,
+// keeping at most maxRecords interactions (0 uses 1000). The returned store
+// is used to inspect and replay them.
+func (d *EnhancedDetector) EnableRecording(maxRecords int) *InteractionStore {
+	if maxRecords <= 0 {
+		maxRecords = 1000
+	}
+	store := &InteractionStore{byID: map[string]Interaction{}, max: maxRecords}
+	d.backend = &recordingBackend{inner: d.backend, store: store}
+	return store
+}
+
+type recordingBackend struct {
+	inner ModelBackend
+	store *InteractionStore
+}
+
+// Classify implements ModelBackend.
+func (b *recordingBackend) Classify(ctx context.Context, text string) (*guard.ThreatResult, error) {
+	result, err := b.inner.Classify(ctx, text)
+	redacted, _ := pii.Redact(text)
+	interaction := Interaction{
+		DecisionID: newDecisionID(),
+		Timestamp:  time.Now(),
+		Text:       redacted,
+		Result:     result,
+	}
+	if err != nil {
+		interaction.Error = err.Error()
+	}
+	b.store.add(interaction)
+	return result, err
+}
+
+func newDecisionID() string {
+	buf := make([]byte, 4)
+	rand.Read(buf)
+	return "D-" + hex.EncodeToString(buf)
+}
+
+func (s *InteractionStore) add(interaction Interaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.byID[interaction.DecisionID] = interaction
+	s.ids = append(s.ids, interaction.DecisionID)
+	for len(s.ids) > s.max {
+		delete(s.byID, s.ids[0])
+		s.ids = s.ids[1:]
+	}
+}
+
+// Get returns one interaction by decision ID.
+func (s *InteractionStore) Get(decisionID string) (Interaction, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	interaction, ok := s.byID[decisionID]
+	return interaction, ok
+}
+
+// All returns the recorded interactions, oldest first.
+func (s *InteractionStore) All() []Interaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	interactions := make([]Interaction, 0, len(s.ids))
+	for _, id := range s.ids {
+		interactions = append(interactions, s.byID[id])
+	}
+	return interactions
+}
+
+// Replay re-sends a recorded interaction's input against another backend
+// and returns the new verdict, e.g. to compare a candidate model or prompt
+// template against a recorded decision.
+func (s *InteractionStore) Replay(ctx context.Context, decisionID string, backend ModelBackend) (*guard.ThreatResult, error) {
+	interaction, ok := s.Get(decisionID)
+	if !ok {
+		return nil, fmt.Errorf("no recorded interaction for decision ID %q", decisionID)
+	}
+	return backend.Classify(ctx, interaction.Text)
+}
+
+// Save writes the recorded interactions as JSONL.
+func (s *InteractionStore) Save(w io.Writer) error {
+	for _, interaction := range s.All() {
+		line, err := json.Marshal(interaction)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load reads JSONL interactions into the store.
+func (s *InteractionStore) Load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var interaction Interaction
+		if err := json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return err
+		}
+		s.add(interaction)
+	}
+	return scanner.Err()
+}
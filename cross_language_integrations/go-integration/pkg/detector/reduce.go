@@ -0,0 +1,111 @@
+package detector
+
+import (
+	"strings"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Long-input reduction for document-heavy workloads: the regex and
+// normalization stages always see the full input, but sending a 200KB
+// document to the model costs tokens and latency it rarely earns. When
+// enabled, a cheap extractive pass shrinks the model-stage input to the
+// head, the tail, and a window around every regex finding — so all pattern
+// evidence survives the reduction, including matches that only appear in
+// the normalized form of the text.
+
+// ReduceConfig controls model-stage input reduction (see EnableReduce).
+type ReduceConfig struct {
+	Enabled bool
+	// MaxLength is the input size in bytes above which reduction kicks in
+	// (0 uses 4096).
+	MaxLength int
+	// Window is how many bytes of context to keep around each regex
+	// finding (0 uses 200).
+	Window int
+}
+
+// EnableReduce turns on extractive input reduction for the model stage.
+func (d *EnhancedDetector) EnableReduce(config ReduceConfig) {
+	if config.MaxLength <= 0 {
+		config.MaxLength = 4096
+	}
+	if config.Window <= 0 {
+		config.Window = 200
+	}
+	config.Enabled = true
+	d.reduce = config
+}
+
+// reduceForModel returns the model-stage input: the text itself when it is
+// short or reduction is off, otherwise an extract preserving every regex
+// finding with context.
+func (d *EnhancedDetector) reduceForModel(text string) string {
+	if !d.reduce.Enabled || len(text) <= d.reduce.MaxLength {
+		return text
+	}
+
+	window := d.reduce.Window
+	spans := widen(guard.MatchSpans(text), window, len(text))
+
+	var parts []string
+	covered := 0
+	appendPart := func(s string) {
+		if s != "" {
+			parts = append(parts, s)
+			covered += len(s)
+		}
+	}
+
+	// Head and tail anchor the extract; injected instructions cluster there.
+	head := window * 2
+	if head > len(text) {
+		head = len(text)
+	}
+	appendPart(text[:head])
+	for _, span := range spans {
+		if span.Start < head {
+			span.Start = head
+		}
+		if span.Start >= span.End {
+			continue
+		}
+		appendPart(text[span.Start:span.End])
+	}
+	tailStart := len(text) - window*2
+	if tailStart < head {
+		tailStart = head
+	}
+	appendPart(text[tailStart:])
+
+	// Matches that only exist after normalization (homoglyphs, zero-width
+	// stripping) have no raw-text offsets; carry their snippets directly.
+	if normalized := guard.Normalize(text).Text; normalized != text {
+		for _, span := range widen(guard.MatchSpans(normalized), window, len(normalized)) {
+			appendPart(normalized[span.Start:span.End])
+			if covered >= d.reduce.MaxLength {
+				break
+			}
+		}
+	}
+
+	return strings.Join(parts, "\n...\n")
+}
+
+// widen grows each span by window bytes on both sides, clamped to the text,
+// and merges the overlaps that creates.
+func widen(spans []guard.Span, window, length int) []guard.Span {
+	widened := make([]guard.Span, 0, len(spans))
+	for _, span := range spans {
+		span.Start -= window
+		if span.Start < 0 {
+			span.Start = 0
+		}
+		span.End += window
+		if span.End > length {
+			span.End = length
+		}
+		widened = append(widened, span)
+	}
+	return guard.MergeSpans(widened)
+}
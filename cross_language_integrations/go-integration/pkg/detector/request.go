@@ -0,0 +1,50 @@
+package detector
+
+import (
+	"context"
+	"strings"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// DetectionRequest is the richer request form for callers that know how the
+// input physically reached the model (e.g. email → attachment → OCR text).
+// The provenance chain is propagated onto the result so events and reports
+// show analysts the full path of an injected instruction.
+type DetectionRequest struct {
+	Text string `json:"text"`
+	// Tenant scopes cache entries and policy configuration.
+	Tenant string `json:"tenant,omitempty"`
+	// SessionID optionally ties the request to a conversation session.
+	SessionID string `json:"session_id,omitempty"`
+	// Provenance lists the hops the input took before reaching the guard,
+	// outermost first.
+	Provenance []guard.ProvenanceHop `json:"provenance,omitempty"`
+}
+
+// DetectRequest runs the full pipeline for a DetectionRequest, attaching the
+// provenance chain to the result.
+func (d *EnhancedDetector) DetectRequest(ctx context.Context, req DetectionRequest) (*guard.ThreatResult, error) {
+	result, err := d.DetectTenant(ctx, req.Tenant, req.Text)
+	if err != nil {
+		return nil, err
+	}
+	if result != nil && len(req.Provenance) > 0 {
+		result.Provenance = req.Provenance
+		if result.IsThreat {
+			result.Reasoning += " (input path: " + formatProvenance(req.Provenance) + ")"
+		}
+	}
+	return result, nil
+}
+
+func formatProvenance(hops []guard.ProvenanceHop) string {
+	parts := make([]string, len(hops))
+	for i, hop := range hops {
+		parts[i] = hop.Medium
+		if hop.Detail != "" {
+			parts[i] += "[" + hop.Detail + "]"
+		}
+	}
+	return strings.Join(parts, " → ")
+}
@@ -0,0 +1,149 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+	"github.com/marcusjohansson/trust-go/pkg/guardmetrics"
+)
+
+// The raw model call used to fail hard on a single transient error and
+// silently fall back to regex. resilientBackend adds retries with jittered
+// exponential backoff, and a circuit breaker so a down endpoint is not
+// hammered by every request while it recovers.
+
+// RetryConfig controls model-call retries.
+type RetryConfig struct {
+	// Attempts is the total number of tries, including the first.
+	Attempts int `json:"attempts"`
+	// Backoff is the base delay before the first retry; it doubles per
+	// retry and is jittered ±50%.
+	Backoff time.Duration `json:"backoff"`
+}
+
+// BreakerConfig controls the circuit breaker.
+type BreakerConfig struct {
+	// FailureThreshold is how many consecutive failed calls open the circuit.
+	FailureThreshold int `json:"failure_threshold"`
+	// OpenDuration is how long an open circuit rejects calls before letting
+	// a probe through (half-open).
+	OpenDuration time.Duration `json:"open_duration"`
+}
+
+// Breaker state gauge values.
+const (
+	breakerClosed   = 0
+	breakerHalfOpen = 1
+	breakerOpen     = 2
+)
+
+type resilientBackend struct {
+	inner   ModelBackend
+	retry   RetryConfig
+	breaker BreakerConfig
+
+	mu       sync.Mutex
+	failures int
+	state    int
+	openedAt time.Time
+}
+
+// WrapResilient wraps a model backend with retries and a circuit breaker.
+// Zero-value config fields get sensible defaults (3 attempts, 100ms base
+// backoff, breaker opens after 5 consecutive failures for 30s).
+func WrapResilient(backend ModelBackend, retry RetryConfig, breaker BreakerConfig) ModelBackend {
+	if retry.Attempts <= 0 {
+		retry.Attempts = 3
+	}
+	if retry.Backoff <= 0 {
+		retry.Backoff = 100 * time.Millisecond
+	}
+	if breaker.FailureThreshold <= 0 {
+		breaker.FailureThreshold = 5
+	}
+	if breaker.OpenDuration <= 0 {
+		breaker.OpenDuration = 30 * time.Second
+	}
+	return &resilientBackend{inner: backend, retry: retry, breaker: breaker}
+}
+
+// EnableResilience wraps the detector's model backend with retries and a
+// circuit breaker.
+func (d *EnhancedDetector) EnableResilience(retry RetryConfig, breaker BreakerConfig) {
+	d.backend = WrapResilient(d.backend, retry, breaker)
+}
+
+// Classify implements ModelBackend.
+func (b *resilientBackend) Classify(ctx context.Context, text string) (*guard.ThreatResult, error) {
+	if !b.allow() {
+		return nil, fmt.Errorf("model circuit breaker open")
+	}
+
+	var lastErr error
+	backoff := b.retry.Backoff
+	for attempt := 0; attempt < b.retry.Attempts; attempt++ {
+		if attempt > 0 {
+			guardmetrics.ModelRetriesTotal.Inc()
+			// Jitter ±50% to avoid synchronized retry storms.
+			delay := time.Duration(float64(backoff) * (0.5 + rand.Float64()))
+			backoff *= 2
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				b.recordFailure()
+				return nil, ctx.Err()
+			}
+		}
+		result, err := b.inner.Classify(ctx, text)
+		if err == nil {
+			b.recordSuccess()
+			return result, nil
+		}
+		lastErr = err
+	}
+	b.recordFailure()
+	return nil, lastErr
+}
+
+// allow reports whether a call may proceed, transitioning an expired open
+// circuit to half-open so one probe goes through.
+func (b *resilientBackend) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state != breakerOpen {
+		return true
+	}
+	if time.Since(b.openedAt) < b.breaker.OpenDuration {
+		return false
+	}
+	b.setState(breakerHalfOpen)
+	return true
+}
+
+func (b *resilientBackend) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.setState(breakerClosed)
+}
+
+func (b *resilientBackend) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	// A failed half-open probe reopens immediately.
+	if b.state == breakerHalfOpen || b.failures >= b.breaker.FailureThreshold {
+		b.openedAt = time.Now()
+		b.setState(breakerOpen)
+	}
+}
+
+// setState updates the state and gauge; callers hold b.mu.
+func (b *resilientBackend) setState(state int) {
+	b.state = state
+	guardmetrics.ModelBreakerState.Set(float64(state))
+}
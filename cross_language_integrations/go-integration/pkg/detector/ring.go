@@ -0,0 +1,122 @@
+package detector
+
+import (
+	"sync"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+	"github.com/marcusjohansson/trust-go/pkg/pii"
+)
+
+// Live decision tailing for incidents: a bounded in-memory ring of the most
+// recent decisions, cheap enough to leave enabled and served without the
+// full audit/persistence stack. guard-server exposes it on an authenticated
+// admin endpoint and `guard-cli tail` polls it.
+
+// DecisionRecord is one pipeline decision in the ring.
+type DecisionRecord struct {
+	// Seq increases by one per decision, so pollers can resume from the
+	// last sequence number they saw.
+	Seq       uint64    `json:"seq"`
+	Timestamp time.Time `json:"timestamp"`
+	// Input is the PII-redacted input, truncated for display.
+	Input     string              `json:"input"`
+	Stage     string              `json:"stage"`
+	Result    *guard.ThreatResult `json:"result,omitempty"`
+	Error     string              `json:"error,omitempty"`
+	LatencyMS float64             `json:"latency_ms"`
+}
+
+// ringInputLimit truncates recorded inputs; the ring is for eyeballing
+// live traffic, not archiving it.
+const ringInputLimit = 200
+
+// DecisionRing is a bounded buffer of recent decisions, oldest evicted
+// first. It is safe for concurrent use.
+type DecisionRing struct {
+	mu      sync.Mutex
+	records []DecisionRecord
+	next    int
+	full    bool
+	seq     uint64
+}
+
+// EnableDecisionRing keeps the last maxRecords decisions (0 uses 256) in
+// memory for live debugging. The returned ring serves Recent and Since.
+func (d *EnhancedDetector) EnableDecisionRing(maxRecords int) *DecisionRing {
+	if maxRecords <= 0 {
+		maxRecords = 256
+	}
+	d.ring = &DecisionRing{records: make([]DecisionRecord, maxRecords)}
+	return d.ring
+}
+
+func (r *DecisionRing) add(record DecisionRecord) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	record.Seq = r.seq
+	r.records[r.next] = record
+	r.next = (r.next + 1) % len(r.records)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// snapshot returns the buffered records oldest-first. Callers must hold r.mu.
+func (r *DecisionRing) snapshot() []DecisionRecord {
+	if !r.full {
+		return r.records[:r.next]
+	}
+	out := make([]DecisionRecord, 0, len(r.records))
+	out = append(out, r.records[r.next:]...)
+	out = append(out, r.records[:r.next]...)
+	return out
+}
+
+// Recent returns up to n of the latest decisions, oldest-first (0 returns
+// everything buffered).
+func (r *DecisionRing) Recent(n int) []DecisionRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	all := r.snapshot()
+	if n > 0 && len(all) > n {
+		all = all[len(all)-n:]
+	}
+	return append([]DecisionRecord(nil), all...)
+}
+
+// Since returns decisions with a sequence number greater than seq,
+// oldest-first, for pollers tailing the ring.
+func (r *DecisionRing) Since(seq uint64) []DecisionRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	all := r.snapshot()
+	start := len(all)
+	for i, record := range all {
+		if record.Seq > seq {
+			start = i
+			break
+		}
+	}
+	return append([]DecisionRecord(nil), all[start:]...)
+}
+
+// recordDecision builds the ring entry for one finished detection.
+func (r *DecisionRing) recordDecision(start time.Time, text, stage string, result *guard.ThreatResult, err error) {
+	redacted, _ := pii.Redact(text)
+	if len(redacted) > ringInputLimit {
+		redacted = redacted[:ringInputLimit] + "..."
+	}
+	record := DecisionRecord{
+		Timestamp: start,
+		Input:     redacted,
+		Stage:     stage,
+		Result:    result,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	r.add(record)
+}
@@ -0,0 +1,46 @@
+package detector
+
+import (
+	"hash/fnv"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Sampling caps what the model backend costs on clean traffic: only a
+// configured fraction of regex-clean inputs reaches the model, chosen
+// deterministically from the input hash so the same input always gets the
+// same treatment (and cache entries stay consistent). Regex-flagged inputs
+// always escalate — sampling never skips the model for anything the
+// pre-filter considers suspicious.
+
+// SamplingConfig controls model-stage sampling of regex-clean traffic.
+type SamplingConfig struct {
+	Enabled bool
+	// ModelRate is the fraction of regex-clean inputs sent to the model,
+	// in [0, 1]. The rest are returned as benign on the regex verdict alone.
+	ModelRate float64
+}
+
+// SetSampling configures model-stage sampling (see SamplingConfig).
+func (d *EnhancedDetector) SetSampling(config SamplingConfig) {
+	d.sampling = config
+}
+
+// sampledOut reports whether a regex-clean input should skip the model
+// stage. The decision hashes the normalized input, so obfuscated variants
+// of the same text fall on the same side of the rate.
+func (d *EnhancedDetector) sampledOut(text string) bool {
+	if !d.sampling.Enabled {
+		return false
+	}
+	rate := d.sampling.ModelRate
+	if rate >= 1 {
+		return false
+	}
+	if rate <= 0 {
+		return true
+	}
+	h := fnv.New64a()
+	h.Write([]byte(guard.Normalize(text).Text))
+	return float64(h.Sum64()%10000)/10000 >= rate
+}
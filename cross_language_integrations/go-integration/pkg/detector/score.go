@@ -0,0 +1,39 @@
+package detector
+
+import (
+	"context"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Score returns a per-category probability vector instead of a single
+// argmax verdict, so downstream systems can apply their own multi-label
+// policies and log richer signals.
+func (d *EnhancedDetector) Score(text string) (map[string]float64, error) {
+	return d.ScoreContext(context.Background(), text)
+}
+
+// ScoreContext combines the regex score vector with the model verdict. Each
+// category carries the maximum of the two signals; "benign" holds the
+// complement of the strongest threat score.
+func (d *EnhancedDetector) ScoreContext(ctx context.Context, text string) (map[string]float64, error) {
+	scores := guard.ScoreRegex(text)
+
+	// The model contributes its single verdict as one more signal. Backend
+	// failures degrade to the regex-only vector rather than erroring, in
+	// line with the Detect fallback behavior.
+	if result, err := d.backend.Classify(ctx, text); err == nil && result != nil && result.IsThreat {
+		if result.Confidence > scores[result.ThreatType] {
+			scores[result.ThreatType] = result.Confidence
+		}
+	}
+
+	maxScore := 0.0
+	for tType, score := range scores {
+		if tType != "benign" && score > maxScore {
+			maxScore = score
+		}
+	}
+	scores["benign"] = 1.0 - maxScore
+	return scores, nil
+}
@@ -0,0 +1,85 @@
+package detector
+
+import (
+	"sync"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/audit"
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+	"github.com/marcusjohansson/trust-go/pkg/guardmetrics"
+	"github.com/marcusjohansson/trust-go/pkg/notify"
+)
+
+// Shadow (dry-run) mode: the detector evaluates everything and verdicts
+// still reach logs and metrics, but block decisions are downgraded to flags
+// so false-positive rates can be measured on real traffic before enforcing.
+
+// ShadowConfig selects which traffic runs in dry-run. Enabled shadows all
+// traffic; Tenants and ThreatTypes shadow selectively.
+type ShadowConfig struct {
+	Enabled bool `json:"enabled"`
+	// Tenants lists tenant IDs whose traffic is never blocked.
+	Tenants map[string]bool `json:"tenants,omitempty"`
+	// ThreatTypes lists threat types that are never enforced, e.g. while a
+	// new rule pack is being tuned.
+	ThreatTypes map[string]bool `json:"threat_types,omitempty"`
+}
+
+var shadowMu sync.RWMutex
+
+// SetShadow replaces the shadow-mode configuration.
+func (d *EnhancedDetector) SetShadow(config ShadowConfig) {
+	shadowMu.Lock()
+	defer shadowMu.Unlock()
+	d.shadow = config
+}
+
+// ShadowConfig returns the active shadow-mode configuration.
+func (d *EnhancedDetector) ShadowConfig() ShadowConfig {
+	shadowMu.RLock()
+	defer shadowMu.RUnlock()
+	return d.shadow
+}
+
+// shadowed reports whether a result for a tenant falls under dry-run.
+func (d *EnhancedDetector) shadowed(tenant string, result *guard.ThreatResult) bool {
+	shadowMu.RLock()
+	defer shadowMu.RUnlock()
+	if d.shadow.Enabled || d.shadow.Tenants[tenant] {
+		return true
+	}
+	if result == nil || !d.shadow.ThreatTypes[result.ThreatType] {
+		return false
+	}
+	// A shadowed primary type must not mute enforced secondary labels.
+	for _, label := range result.Secondary {
+		if !d.shadow.ThreatTypes[label.ThreatType] {
+			return false
+		}
+	}
+	return true
+}
+
+// Enforce evaluates policy for a result on behalf of a tenant and applies
+// shadow mode: a would-be block under dry-run is counted and downgraded to
+// ActionFlag. Blocked high-severity threats are pushed to the configured
+// webhook notifier.
+func (d *EnhancedDetector) Enforce(tenant string, policy guard.Policy, input string, result *guard.ThreatResult) guard.Action {
+	action := policy.Evaluate(result)
+	if action != guard.ActionBlock && action != guard.ActionSoftBlock {
+		return action
+	}
+	if d.shadowed(tenant, result) {
+		guardmetrics.ShadowedBlocksTotal.WithLabelValues(result.ThreatType).Inc()
+		return guard.ActionFlag
+	}
+	if d.notifier != nil && action == guard.ActionBlock &&
+		(result.Severity == guard.SeverityHigh || result.Severity == guard.SeverityCritical) {
+		d.notifier.Notify(notify.Event{
+			Timestamp: time.Now(),
+			InputHash: audit.HashInput(input),
+			Result:    result,
+		})
+	}
+	return action
+}
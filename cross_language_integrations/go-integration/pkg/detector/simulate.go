@@ -0,0 +1,78 @@
+package detector
+
+import (
+	"context"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Policy simulation: product teams submit a candidate policy plus a sample
+// of their own traffic and get back the block/flag rates that policy would
+// produce, per threat category. Runs entirely off the production path —
+// nothing is enforced, notified, or cached.
+
+// CategoryProjection is the projected outcome for one threat category.
+type CategoryProjection struct {
+	Samples   int `json:"samples"`
+	Blocked   int `json:"blocked"`
+	Flagged   int `json:"flagged"`
+	SoftBlock int `json:"soft_blocked,omitempty"`
+	Allowed   int `json:"allowed"`
+}
+
+// SimulationReport summarizes a policy simulation over a traffic sample.
+type SimulationReport struct {
+	Samples int `json:"samples"`
+	// Errors counts samples the pipeline failed to classify; they are
+	// excluded from the rates.
+	Errors     int                            `json:"errors,omitempty"`
+	BlockRate  float64                        `json:"block_rate"`
+	FlagRate   float64                        `json:"flag_rate"`
+	ByCategory map[string]*CategoryProjection `json:"by_category"`
+}
+
+// Simulate classifies each sample and evaluates the candidate policy
+// against the verdicts. The cache is bypassed so results reflect the
+// current pipeline, and no enforcement side effects fire.
+func (d *EnhancedDetector) Simulate(ctx context.Context, policy guard.Policy, samples []string) *SimulationReport {
+	report := &SimulationReport{
+		Samples:    len(samples),
+		ByCategory: map[string]*CategoryProjection{},
+	}
+	blocked, flagged := 0, 0
+	for _, sample := range samples {
+		result, err := d.DetectUncached(ctx, sample)
+		if err != nil {
+			report.Errors++
+			continue
+		}
+		category := "benign"
+		if result.IsThreat {
+			category = result.ThreatType
+		}
+		projection := report.ByCategory[category]
+		if projection == nil {
+			projection = &CategoryProjection{}
+			report.ByCategory[category] = projection
+		}
+		projection.Samples++
+		switch policy.Evaluate(result) {
+		case guard.ActionBlock:
+			projection.Blocked++
+			blocked++
+		case guard.ActionSoftBlock:
+			projection.SoftBlock++
+			blocked++
+		case guard.ActionFlag:
+			projection.Flagged++
+			flagged++
+		default:
+			projection.Allowed++
+		}
+	}
+	if classified := report.Samples - report.Errors; classified > 0 {
+		report.BlockRate = float64(blocked) / float64(classified)
+		report.FlagRate = float64(flagged) / float64(classified)
+	}
+	return report
+}
@@ -0,0 +1,43 @@
+package detector
+
+import (
+	"github.com/marcusjohansson/trust-go/pkg/extract"
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// EnableContentExtraction parses HTML/Markdown inputs into regions (see
+// pkg/extract) and scans each concealed region — comments, alt text,
+// hidden elements, link titles — on its own. An instruction that only
+// exists where a rendered page would never show it is treated as high
+// confidence regardless of how it scores in the full document.
+func (d *EnhancedDetector) EnableContentExtraction() {
+	d.structured = true
+}
+
+// scanHiddenRegions returns the strongest verdict found inside concealed
+// markup regions, nil when every hidden region is clean.
+func scanHiddenRegions(text string) *guard.ThreatResult {
+	var best *guard.ThreatResult
+	for _, region := range extract.Regions(text) {
+		if !region.Hidden {
+			continue
+		}
+		result := guard.CheckRegex(region.Text)
+		if result == nil {
+			result = guard.CheckEncoded(region.Text)
+		}
+		if result == nil {
+			continue
+		}
+		// Concealment is itself a signal: the author did not want a human
+		// reviewer to see this instruction.
+		if result.Confidence < 0.9 {
+			result.Confidence = 0.9
+		}
+		result.Reasoning = "Concealed in " + region.Kind + ": " + result.Reasoning
+		if best == nil || result.Confidence > best.Confidence {
+			best = result
+		}
+	}
+	return best
+}
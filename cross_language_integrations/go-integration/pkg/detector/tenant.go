@@ -0,0 +1,202 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+	"github.com/marcusjohansson/trust-go/pkg/rules"
+)
+
+// Multi-tenant deployments used to run one guard process per customer
+// because the detector's configuration is global. MultiTenantDetector keeps
+// one shared model backend and gives every tenant its own pipeline on top:
+// private rule set, policy, result cache, and rate limit, all resolved per
+// Detect call from the tenant ID.
+
+// TenantConfig describes one tenant's isolated detection setup. The zero
+// value runs the shared patterns with the default policy and no cache or
+// rate limit.
+type TenantConfig struct {
+	// Rules is a tenant-private composite rule set (see pkg/rules) layered
+	// on top of the shared regex patterns.
+	Rules *rules.Set
+	// Policy decides block/flag/allow for this tenant's verdicts; nil falls
+	// back to guard.DefaultPolicy. Per-tenant thresholds live here.
+	Policy *guard.Policy
+	// Cache configures a tenant-private result cache; the zero value
+	// disables caching for the tenant.
+	Cache CacheConfig
+	// RequestsPerMinute caps this tenant's detection calls; 0 means
+	// unlimited. Calls over the cap fail with ErrRateLimited.
+	RequestsPerMinute int
+}
+
+// ErrRateLimited is returned when a tenant exceeds its configured
+// detection-call rate.
+var ErrRateLimited = fmt.Errorf("tenant rate limit exceeded")
+
+// tenantRuntime is the per-tenant pipeline built from a TenantConfig.
+type tenantRuntime struct {
+	det     *EnhancedDetector
+	policy  guard.Policy
+	limiter *rateWindow
+}
+
+// MultiTenantDetector routes detection calls to per-tenant pipelines that
+// share one model backend. It is safe for concurrent use.
+type MultiTenantDetector struct {
+	configPath string
+	regexPath  string
+	backend    ModelBackend
+	defaults   TenantConfig
+
+	mu      sync.RWMutex
+	tenants map[string]*tenantRuntime
+}
+
+// NewMultiTenant creates a multi-tenant detector. defaults applies to
+// tenants without an explicit SetTenant call, so a new customer works
+// before it has custom configuration.
+func NewMultiTenant(configPath, regexPath string, backend ModelBackend, defaults TenantConfig) (*MultiTenantDetector, error) {
+	if backend == nil {
+		return nil, fmt.Errorf("model backend must not be nil")
+	}
+	return &MultiTenantDetector{
+		configPath: configPath,
+		regexPath:  regexPath,
+		backend:    backend,
+		defaults:   defaults,
+		tenants:    make(map[string]*tenantRuntime),
+	}, nil
+}
+
+// SetTenant installs (or replaces) one tenant's configuration. The tenant's
+// cache starts empty, so a config change never serves decisions made under
+// the old configuration.
+func (m *MultiTenantDetector) SetTenant(tenant string, config TenantConfig) error {
+	if tenant == "" {
+		return fmt.Errorf("tenant ID must not be empty")
+	}
+	runtime, err := m.buildRuntime(config)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tenants[tenant] = runtime
+	return nil
+}
+
+// RemoveTenant drops a tenant's configuration; subsequent calls for that
+// tenant fall back to the defaults.
+func (m *MultiTenantDetector) RemoveTenant(tenant string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.tenants, tenant)
+}
+
+func (m *MultiTenantDetector) buildRuntime(config TenantConfig) (*tenantRuntime, error) {
+	det, err := NewEnhancedWithBackend(m.configPath, m.regexPath, m.backend)
+	if err != nil {
+		return nil, err
+	}
+	if config.Rules != nil {
+		det.EnableRules(config.Rules)
+	}
+	if config.Cache.MaxEntries > 0 {
+		det.EnableCache(config.Cache)
+	}
+	policy := guard.DefaultPolicy()
+	if config.Policy != nil {
+		policy = *config.Policy
+	}
+	runtime := &tenantRuntime{det: det, policy: policy}
+	if config.RequestsPerMinute > 0 {
+		runtime.limiter = newRateWindow(config.RequestsPerMinute, time.Minute)
+	}
+	return runtime, nil
+}
+
+// runtime resolves a tenant's pipeline, lazily building one from the
+// defaults for tenants never configured explicitly.
+func (m *MultiTenantDetector) runtime(tenant string) (*tenantRuntime, error) {
+	m.mu.RLock()
+	runtime, ok := m.tenants[tenant]
+	m.mu.RUnlock()
+	if ok {
+		return runtime, nil
+	}
+	built, err := m.buildRuntime(m.defaults)
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if existing, ok := m.tenants[tenant]; ok {
+		return existing, nil
+	}
+	m.tenants[tenant] = built
+	return built, nil
+}
+
+// Detect runs the tenant's pipeline on the input. It fails with
+// ErrRateLimited when the tenant is over its configured rate.
+func (m *MultiTenantDetector) Detect(ctx context.Context, tenant, text string) (*guard.ThreatResult, error) {
+	runtime, err := m.runtime(tenant)
+	if err != nil {
+		return nil, err
+	}
+	if runtime.limiter != nil && !runtime.limiter.allow() {
+		return nil, ErrRateLimited
+	}
+	return runtime.det.DetectTenant(ctx, tenant, text)
+}
+
+// Enforce applies the tenant's policy to a detection result.
+func (m *MultiTenantDetector) Enforce(tenant, input string, result *guard.ThreatResult) guard.Action {
+	runtime, err := m.runtime(tenant)
+	if err != nil {
+		return guard.DefaultPolicy().Evaluate(result)
+	}
+	return runtime.det.Enforce(tenant, runtime.policy, input, result)
+}
+
+// Policy returns the tenant's active policy.
+func (m *MultiTenantDetector) Policy(tenant string) guard.Policy {
+	runtime, err := m.runtime(tenant)
+	if err != nil {
+		return guard.DefaultPolicy()
+	}
+	return runtime.policy
+}
+
+// rateWindow is a fixed-window request counter.
+type rateWindow struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	start  time.Time
+	count  int
+}
+
+func newRateWindow(limit int, window time.Duration) *rateWindow {
+	return &rateWindow{limit: limit, window: window}
+}
+
+func (r *rateWindow) allow() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	if now.Sub(r.start) >= r.window {
+		r.start = now
+		r.count = 0
+	}
+	if r.count >= r.limit {
+		return false
+	}
+	r.count++
+	return true
+}
@@ -0,0 +1,127 @@
+// Package embedding flags inputs that are semantically close to known
+// attack prompts. Regexes miss paraphrased versions of well-known
+// jailbreaks (DAN and friends); a cosine-similarity lookup against an
+// embedded corpus of confirmed attacks catches the rewordings.
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Embedder turns text into a vector. Implementations wrap whatever
+// embedding model the deployment has available.
+type Embedder interface {
+	Embed(ctx context.Context, text string) ([]float32, error)
+}
+
+// entry is one known attack prompt in the index.
+type entry struct {
+	prompt     string
+	threatType string
+	vector     []float32
+}
+
+// Index is an in-memory corpus of embedded attack prompts.
+type Index struct {
+	embedder Embedder
+
+	mu      sync.RWMutex
+	entries []entry
+}
+
+// NewIndex creates an empty index using the given embedder.
+func NewIndex(embedder Embedder) *Index {
+	return &Index{embedder: embedder}
+}
+
+// Add embeds a known attack prompt and stores it under a threat type.
+func (ix *Index) Add(ctx context.Context, prompt, threatType string) error {
+	vector, err := ix.embedder.Embed(ctx, prompt)
+	if err != nil {
+		return fmt.Errorf("embedding corpus prompt: %w", err)
+	}
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.entries = append(ix.entries, entry{prompt: prompt, threatType: threatType, vector: normalize(vector)})
+	return nil
+}
+
+// Len returns the number of indexed prompts.
+func (ix *Index) Len() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.entries)
+}
+
+// Check embeds text and flags it when its cosine similarity to any indexed
+// attack prompt reaches threshold. It returns nil when nothing is close.
+func (ix *Index) Check(ctx context.Context, text string, threshold float64) (*guard.ThreatResult, error) {
+	ix.mu.RLock()
+	empty := len(ix.entries) == 0
+	ix.mu.RUnlock()
+	if empty {
+		return nil, nil
+	}
+
+	vector, err := ix.embedder.Embed(ctx, text)
+	if err != nil {
+		return nil, fmt.Errorf("embedding input: %w", err)
+	}
+	vector = normalize(vector)
+
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	bestSim, bestIdx := 0.0, -1
+	for i, e := range ix.entries {
+		if sim := dot(vector, e.vector); sim > bestSim {
+			bestSim, bestIdx = sim, i
+		}
+	}
+	if bestIdx < 0 || bestSim < threshold {
+		return nil, nil
+	}
+
+	matched := ix.entries[bestIdx]
+	return &guard.ThreatResult{
+		IsThreat:   true,
+		ThreatType: matched.threatType,
+		Confidence: bestSim,
+		Reasoning:  fmt.Sprintf("Semantically similar (%.2f) to known attack prompt", bestSim),
+		Severity:   guard.SeverityForType(matched.threatType),
+	}, nil
+}
+
+// dot assumes both vectors are already normalized, making it the cosine
+// similarity.
+func dot(a, b []float32) float64 {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	var sum float64
+	for i := 0; i < n; i++ {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum
+}
+
+func normalize(v []float32) []float32 {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	norm := math.Sqrt(sum)
+	if norm == 0 {
+		return v
+	}
+	out := make([]float32, len(v))
+	for i, x := range v {
+		out[i] = float32(float64(x) / norm)
+	}
+	return out
+}
@@ -0,0 +1,76 @@
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// OpenAIEmbedder calls an OpenAI-compatible /v1/embeddings endpoint, which
+// covers OpenAI itself plus the many local servers (Ollama, vLLM,
+// llama.cpp) that mirror the API.
+type OpenAIEmbedder struct {
+	BaseURL string
+	APIKey  string
+	Model   string
+	Client  *http.Client
+}
+
+// NewOpenAIEmbedder creates an embedder for an OpenAI-compatible API.
+func NewOpenAIEmbedder(baseURL, apiKey, model string) *OpenAIEmbedder {
+	return &OpenAIEmbedder{
+		BaseURL: baseURL,
+		APIKey:  apiKey,
+		Model:   model,
+		Client:  &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+type embeddingRequest struct {
+	Model string `json:"model"`
+	Input string `json:"input"`
+}
+
+type embeddingResponse struct {
+	Data []struct {
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+// Embed implements Embedder.
+func (e *OpenAIEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	body, err := json.Marshal(embeddingRequest{Model: e.Model, Input: text})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		e.BaseURL+"/v1/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.APIKey)
+	}
+
+	resp, err := e.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("embeddings API returned status: %s", resp.Status)
+	}
+
+	var decoded embeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return nil, err
+	}
+	if len(decoded.Data) == 0 {
+		return nil, fmt.Errorf("embeddings API returned no data")
+	}
+	return decoded.Data[0].Embedding, nil
+}
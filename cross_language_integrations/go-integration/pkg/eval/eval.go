@@ -0,0 +1,116 @@
+package eval
+
+import (
+	"fmt"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Sample is one benchmark prompt with its ground-truth label.
+type Sample struct {
+	Prompt string `json:"prompt"`
+	// Malicious is the ground truth: true for attack prompts.
+	Malicious bool   `json:"malicious"`
+	Category  string `json:"category,omitempty"`
+	Source    string `json:"source,omitempty"`
+}
+
+// Detector is the minimal surface the evaluator needs, satisfied by
+// *detector.EnhancedDetector and by regex-only wrappers.
+type Detector interface {
+	Detect(text string) (*guard.ThreatResult, error)
+}
+
+// CategoryStats aggregates results for one benchmark category.
+type CategoryStats struct {
+	Total    int `json:"total"`
+	Detected int `json:"detected"`
+}
+
+// Report summarizes detector performance on a benchmark run. Track it per
+// rule-set version to catch recall regressions between releases.
+type Report struct {
+	RuleSetVersion string  `json:"rule_set_version,omitempty"`
+	Total          int     `json:"total"`
+	Malicious      int     `json:"malicious"`
+	Benign         int     `json:"benign"`
+	TruePositives  int     `json:"true_positives"`
+	FalseNegatives int     `json:"false_negatives"`
+	FalsePositives int     `json:"false_positives"`
+	TrueNegatives  int     `json:"true_negatives"`
+	Recall         float64 `json:"recall"`
+	Precision      float64 `json:"precision"`
+	Errors         int     `json:"errors"`
+
+	ByCategory map[string]CategoryStats `json:"by_category,omitempty"`
+}
+
+// Evaluate runs every sample through the detector and scores the outcomes.
+func Evaluate(det Detector, samples []Sample) (*Report, error) {
+	if det == nil {
+		return nil, fmt.Errorf("detector must not be nil")
+	}
+	report := &Report{
+		Total:      len(samples),
+		ByCategory: map[string]CategoryStats{},
+	}
+
+	for _, sample := range samples {
+		result, err := det.Detect(sample.Prompt)
+		if err != nil {
+			report.Errors++
+			continue
+		}
+		detected := result != nil && result.IsThreat
+
+		if sample.Malicious {
+			report.Malicious++
+			if detected {
+				report.TruePositives++
+			} else {
+				report.FalseNegatives++
+			}
+			stats := report.ByCategory[sample.Category]
+			stats.Total++
+			if detected {
+				stats.Detected++
+			}
+			report.ByCategory[sample.Category] = stats
+		} else {
+			report.Benign++
+			if detected {
+				report.FalsePositives++
+			} else {
+				report.TrueNegatives++
+			}
+		}
+	}
+
+	if report.TruePositives+report.FalseNegatives > 0 {
+		report.Recall = float64(report.TruePositives) / float64(report.TruePositives+report.FalseNegatives)
+	}
+	if report.TruePositives+report.FalsePositives > 0 {
+		report.Precision = float64(report.TruePositives) / float64(report.TruePositives+report.FalsePositives)
+	}
+	return report, nil
+}
+
+// RegexOnly adapts the regex baseline to the Detector interface so the two
+// pipelines can be benchmarked independently.
+type RegexOnly struct{}
+
+// Detect runs only the regex and encoded-payload stages.
+func (RegexOnly) Detect(text string) (*guard.ThreatResult, error) {
+	if result := guard.CheckRegex(text); result != nil {
+		return result, nil
+	}
+	if result := guard.CheckEncoded(text); result != nil {
+		return result, nil
+	}
+	return &guard.ThreatResult{
+		IsThreat:   false,
+		ThreatType: "benign",
+		Confidence: 0.0,
+		Reasoning:  "No regex patterns matched",
+	}, nil
+}
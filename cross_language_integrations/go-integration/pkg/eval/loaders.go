@@ -0,0 +1,134 @@
+package eval
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Loaders for community benchmark formats. These files are not shipped
+// in-tree; download them from the benchmark repositories and point the
+// loader at the local copy.
+
+// jailbreakBenchRecord covers JailbreakBench-style JSON entries. Field names
+// vary slightly across releases, so several aliases are accepted.
+type jailbreakBenchRecord struct {
+	Prompt   string `json:"prompt"`
+	Goal     string `json:"goal"`
+	Behavior string `json:"behavior"`
+	Category string `json:"category"`
+	Label    string `json:"label"`
+}
+
+func (r jailbreakBenchRecord) text() string {
+	for _, candidate := range []string{r.Prompt, r.Goal, r.Behavior} {
+		if candidate != "" {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// LoadJailbreakBenchJSON reads a JailbreakBench-style JSON file: either a
+// top-level array of records or an object whose values are arrays of
+// records. All prompts are labeled malicious unless a record carries
+// label "benign".
+func LoadJailbreakBenchJSON(path string) ([]Sample, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []jailbreakBenchRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		// Try the keyed-by-category object layout.
+		var grouped map[string][]jailbreakBenchRecord
+		if err2 := json.Unmarshal(data, &grouped); err2 != nil {
+			return nil, fmt.Errorf("unrecognized benchmark JSON layout: %w", err)
+		}
+		for category, group := range grouped {
+			for _, rec := range group {
+				if rec.Category == "" {
+					rec.Category = category
+				}
+				records = append(records, rec)
+			}
+		}
+	}
+
+	samples := make([]Sample, 0, len(records))
+	for _, rec := range records {
+		text := rec.text()
+		if text == "" {
+			continue
+		}
+		samples = append(samples, Sample{
+			Prompt:    text,
+			Malicious: !strings.EqualFold(rec.Label, "benign"),
+			Category:  rec.Category,
+			Source:    path,
+		})
+	}
+	return samples, nil
+}
+
+// LoadAdvBenchCSV reads an AdvBench-style CSV (harmful_behaviors.csv with a
+// "goal" column, or any CSV with a prompt/goal/behavior column). Every row
+// is labeled malicious.
+func LoadAdvBenchCSV(path string) ([]Sample, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	promptCol := -1
+	categoryCol := -1
+	for i, name := range header {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "goal", "prompt", "behavior", "text":
+			if promptCol < 0 {
+				promptCol = i
+			}
+		case "category":
+			categoryCol = i
+		}
+	}
+	if promptCol < 0 {
+		return nil, fmt.Errorf("no prompt/goal/behavior column in %s (header: %v)", path, header)
+	}
+
+	var samples []Sample
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if promptCol >= len(row) || row[promptCol] == "" {
+			continue
+		}
+		sample := Sample{
+			Prompt:    row[promptCol],
+			Malicious: true,
+			Source:    path,
+		}
+		if categoryCol >= 0 && categoryCol < len(row) {
+			sample.Category = row[categoryCol]
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
@@ -0,0 +1,85 @@
+// Package events publishes detection verdicts to message brokers so
+// analytics and ML teams can consume blocked-prompt streams without
+// touching the detection hot path. Messages are schema-versioned JSON;
+// consumers dispatch on the schema_version field.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// SchemaVersion is the current verdict message schema.
+const SchemaVersion = 1
+
+// Verdict is one detection decision as published to a broker.
+type Verdict struct {
+	SchemaVersion int                 `json:"schema_version"`
+	Timestamp     time.Time           `json:"timestamp"`
+	Tenant        string              `json:"tenant,omitempty"`
+	InputHash     string              `json:"input_hash"`
+	Result        *guard.ThreatResult `json:"result"`
+	Action        guard.Action        `json:"action"`
+}
+
+// Marshal encodes a verdict, stamping the current schema version.
+func Marshal(verdict Verdict) ([]byte, error) {
+	verdict.SchemaVersion = SchemaVersion
+	return json.Marshal(verdict)
+}
+
+// Publisher emits verdicts to a broker.
+type Publisher interface {
+	Publish(ctx context.Context, verdict Verdict) error
+	Close() error
+}
+
+// AsyncPublisher wraps a Publisher with a buffered queue and background
+// worker, decoupling broker latency from the detection path. Verdicts are
+// dropped when the buffer is full.
+type AsyncPublisher struct {
+	inner Publisher
+	queue chan Verdict
+	wg    sync.WaitGroup
+}
+
+// NewAsyncPublisher starts an async wrapper around p with the given buffer
+// size (0 uses a default of 1024).
+func NewAsyncPublisher(p Publisher, buffer int) *AsyncPublisher {
+	if buffer <= 0 {
+		buffer = 1024
+	}
+	a := &AsyncPublisher{inner: p, queue: make(chan Verdict, buffer)}
+	a.wg.Add(1)
+	go a.run()
+	return a
+}
+
+// Publish enqueues a verdict without blocking.
+func (a *AsyncPublisher) Publish(_ context.Context, verdict Verdict) error {
+	select {
+	case a.queue <- verdict:
+	default:
+	}
+	return nil
+}
+
+// Close drains the queue, stops the worker, and closes the wrapped publisher.
+func (a *AsyncPublisher) Close() error {
+	close(a.queue)
+	a.wg.Wait()
+	return a.inner.Close()
+}
+
+func (a *AsyncPublisher) run() {
+	defer a.wg.Done()
+	for verdict := range a.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		a.inner.Publish(ctx, verdict)
+		cancel()
+	}
+}
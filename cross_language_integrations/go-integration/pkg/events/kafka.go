@@ -0,0 +1,41 @@
+package events
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaPublisher publishes verdicts to a Kafka topic, keyed by input hash
+// so retries of the same input land in the same partition.
+type KafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+// NewKafkaPublisher creates a publisher for the given brokers and topic.
+func NewKafkaPublisher(brokers []string, topic string) *KafkaPublisher {
+	return &KafkaPublisher{
+		writer: &kafka.Writer{
+			Addr:     kafka.TCP(brokers...),
+			Topic:    topic,
+			Balancer: &kafka.Hash{},
+		},
+	}
+}
+
+// Publish emits one verdict.
+func (p *KafkaPublisher) Publish(ctx context.Context, verdict Verdict) error {
+	payload, err := Marshal(verdict)
+	if err != nil {
+		return err
+	}
+	return p.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(verdict.InputHash),
+		Value: payload,
+	})
+}
+
+// Close flushes and closes the underlying writer.
+func (p *KafkaPublisher) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,38 @@
+package events
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+)
+
+// NATSPublisher publishes verdicts to a NATS subject.
+type NATSPublisher struct {
+	conn    *nats.Conn
+	subject string
+}
+
+// NewNATSPublisher connects to a NATS server and publishes to subject.
+func NewNATSPublisher(url, subject string) (*NATSPublisher, error) {
+	conn, err := nats.Connect(url)
+	if err != nil {
+		return nil, err
+	}
+	return &NATSPublisher{conn: conn, subject: subject}, nil
+}
+
+// Publish emits one verdict.
+func (p *NATSPublisher) Publish(_ context.Context, verdict Verdict) error {
+	payload, err := Marshal(verdict)
+	if err != nil {
+		return err
+	}
+	return p.conn.Publish(p.subject, payload)
+}
+
+// Close flushes pending messages and closes the connection.
+func (p *NATSPublisher) Close() error {
+	p.conn.Flush()
+	p.conn.Close()
+	return nil
+}
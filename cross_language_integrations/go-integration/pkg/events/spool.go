@@ -0,0 +1,239 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guardmetrics"
+)
+
+// SpoolPublisher wraps a Publisher with a bounded on-disk spool. While the
+// sink is healthy verdicts pass straight through; when a publish fails the
+// verdict is appended to a JSONL spool file instead, and a background loop
+// drains the spool once the sink recovers. The spool is capped so a
+// long outage degrades to dropping events rather than filling the disk.
+type SpoolPublisher struct {
+	inner      Publisher
+	path       string
+	maxRecords int
+
+	mu      sync.Mutex
+	spooled int
+	file    *os.File
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewSpoolPublisher wraps p with a spool file in dir, keeping at most
+// maxRecords spooled verdicts (0 uses 10000). Any verdicts left over from a
+// previous run are counted and drained on startup.
+func NewSpoolPublisher(p Publisher, dir string, maxRecords int) (*SpoolPublisher, error) {
+	if maxRecords <= 0 {
+		maxRecords = 10000
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("creating spool directory: %w", err)
+	}
+	s := &SpoolPublisher{
+		inner:      p,
+		path:       filepath.Join(dir, "verdicts.spool"),
+		maxRecords: maxRecords,
+		stop:       make(chan struct{}),
+	}
+	s.spooled = s.countExisting()
+	guardmetrics.EventSpoolDepth.Set(float64(s.spooled))
+	s.wg.Add(1)
+	go s.drainLoop()
+	return s, nil
+}
+
+// Publish sends the verdict to the sink, spooling it on failure.
+func (s *SpoolPublisher) Publish(ctx context.Context, verdict Verdict) error {
+	if err := s.inner.Publish(ctx, verdict); err != nil {
+		s.spool(verdict)
+	}
+	return nil
+}
+
+// Depth returns the number of verdicts currently spooled on disk.
+func (s *SpoolPublisher) Depth() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.spooled
+}
+
+// Close stops the drain loop and closes the wrapped publisher. Spooled
+// verdicts stay on disk and are drained on the next start.
+func (s *SpoolPublisher) Close() error {
+	close(s.stop)
+	s.wg.Wait()
+	s.mu.Lock()
+	if s.file != nil {
+		s.file.Close()
+		s.file = nil
+	}
+	s.mu.Unlock()
+	return s.inner.Close()
+}
+
+func (s *SpoolPublisher) spool(verdict Verdict) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.spooled >= s.maxRecords {
+		guardmetrics.EventSpoolDropsTotal.Inc()
+		return
+	}
+	if s.file == nil {
+		file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			guardmetrics.EventSpoolDropsTotal.Inc()
+			return
+		}
+		s.file = file
+	}
+	line, err := Marshal(verdict)
+	if err != nil {
+		guardmetrics.EventSpoolDropsTotal.Inc()
+		return
+	}
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		guardmetrics.EventSpoolDropsTotal.Inc()
+		return
+	}
+	s.spooled++
+	guardmetrics.EventSpoolDepth.Set(float64(s.spooled))
+}
+
+// countExisting counts verdicts left in the spool by a previous run.
+func (s *SpoolPublisher) countExisting() int {
+	file, err := os.Open(s.path)
+	if err != nil {
+		return 0
+	}
+	defer file.Close()
+	count := 0
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		count++
+	}
+	return count
+}
+
+func (s *SpoolPublisher) drainLoop() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.drain()
+		}
+	}
+}
+
+// drain replays spooled verdicts in order, stopping at the first publish
+// failure so ordering is preserved across retries. Verdicts that survive
+// the attempt are rewritten to the spool file.
+func (s *SpoolPublisher) drain() {
+	s.mu.Lock()
+	if s.spooled == 0 {
+		s.mu.Unlock()
+		return
+	}
+	if s.file != nil {
+		s.file.Close()
+		s.file = nil
+	}
+	file, err := os.Open(s.path)
+	if err != nil {
+		s.mu.Unlock()
+		return
+	}
+	// Remember how much of the file this snapshot covers; anything past it
+	// was appended while the lock is released below and must survive.
+	var snapshotEnd int64
+	if info, err := file.Stat(); err == nil {
+		snapshotEnd = info.Size()
+	}
+	var verdicts []Verdict
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var verdict Verdict
+		if err := json.Unmarshal(scanner.Bytes(), &verdict); err != nil {
+			continue
+		}
+		verdicts = append(verdicts, verdict)
+	}
+	file.Close()
+	s.mu.Unlock()
+
+	sent := 0
+	for _, verdict := range verdicts {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		err := s.inner.Publish(ctx, verdict)
+		cancel()
+		if err != nil {
+			break
+		}
+		sent++
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Close any handle spool opened during the publish window, so appends
+	// after the rewrite below reopen the new file rather than a renamed or
+	// deleted inode.
+	if s.file != nil {
+		s.file.Close()
+		s.file = nil
+	}
+	remaining := verdicts[sent:]
+	// Verdicts spooled while the sink was being replayed sit past the
+	// snapshot boundary; carry them over verbatim.
+	var tail []byte
+	tailCount := 0
+	if file, err := os.Open(s.path); err == nil {
+		if _, err := file.Seek(snapshotEnd, io.SeekStart); err == nil {
+			tail, _ = io.ReadAll(file)
+		}
+		file.Close()
+		for _, b := range tail {
+			if b == '\n' {
+				tailCount++
+			}
+		}
+	}
+	if len(remaining) == 0 && tailCount == 0 {
+		os.Remove(s.path)
+	} else {
+		tmp := s.path + ".tmp"
+		file, err := os.OpenFile(tmp, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return
+		}
+		for _, verdict := range remaining {
+			line, err := Marshal(verdict)
+			if err != nil {
+				continue
+			}
+			file.Write(append(line, '\n'))
+		}
+		file.Write(tail)
+		file.Close()
+		os.Rename(tmp, s.path)
+	}
+	s.spooled = len(remaining) + tailCount
+	guardmetrics.EventSpoolDepth.Set(float64(s.spooled))
+}
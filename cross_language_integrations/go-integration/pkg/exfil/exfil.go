@@ -0,0 +1,181 @@
+// Package exfil detects data-exfiltration channels in model output. The
+// classic indirect-injection payoff is a markdown image whose URL carries
+// encoded conversation data — the client renders the output, the image
+// auto-loads, and the attacker's server receives the data without a click.
+// The scanner extracts every URL from an output, classifies the channel
+// (image, link, bare URL), and flags destinations off the allowlist that
+// carry encoded payloads; images are flagged on destination alone.
+package exfil
+
+import (
+	"math"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Config tunes the scanner.
+type Config struct {
+	// AllowedDomains lists destinations model output may reference,
+	// matching the domain itself and any subdomain.
+	AllowedDomains []string
+}
+
+// Finding is one URL-shaped exfiltration channel in an output.
+type Finding struct {
+	// Kind is markdown_image, markdown_link, or bare_url.
+	Kind   string `json:"kind"`
+	URL    string `json:"url"`
+	Domain string `json:"domain"`
+	// Reasons says why the finding is suspicious (unlisted_domain,
+	// encoded_payload, zero_click_channel).
+	Reasons []string `json:"reasons"`
+}
+
+var (
+	mdImage = regexp.MustCompile(`!\[[^\]]*\]\(\s*(https?://[^)\s]+)`)
+	mdLink  = regexp.MustCompile(`[^!\]]\[[^\]]*\]\(\s*(https?://[^)\s]+)`)
+	bareURL = regexp.MustCompile(`https?://[^\s)\]}"'<>]+`)
+	// payloadSegment is a run long enough to smuggle data, in base64/hex
+	// shape.
+	payloadSegment = regexp.MustCompile(`[A-Za-z0-9+/_=-]{24,}`)
+)
+
+// Scanner checks model output for exfiltration channels. It is stateless
+// and safe for concurrent use.
+type Scanner struct {
+	config Config
+}
+
+// NewScanner creates a scanner with the given allowlist.
+func NewScanner(config Config) *Scanner {
+	return &Scanner{config: config}
+}
+
+// Scan returns every suspicious URL in the output. Allowlisted
+// destinations are never reported.
+func (s *Scanner) Scan(output string) []Finding {
+	var findings []Finding
+	seen := map[string]bool{}
+	collect := func(kind string, matches [][]string, group int) {
+		for _, match := range matches {
+			raw := strings.TrimRight(match[group], ".,;")
+			if seen[raw] {
+				continue
+			}
+			if finding, suspicious := s.classify(kind, raw); suspicious {
+				seen[raw] = true
+				findings = append(findings, finding)
+			}
+		}
+	}
+
+	collect("markdown_image", mdImage.FindAllStringSubmatch(output, -1), 1)
+	collect("markdown_link", mdLink.FindAllStringSubmatch(output, -1), 1)
+	var bare [][]string
+	for _, match := range bareURL.FindAllString(output, -1) {
+		bare = append(bare, []string{match})
+	}
+	collect("bare_url", bare, 0)
+	return findings
+}
+
+// Check wraps Scan into a threat verdict: any suspicious finding makes the
+// output a data_exfiltration threat, with markdown images (zero-click) at
+// higher confidence than links the user would have to follow.
+func (s *Scanner) Check(output string) *guard.ThreatResult {
+	findings := s.Scan(output)
+	if len(findings) == 0 {
+		return nil
+	}
+	confidence := 0.85
+	worst := findings[0]
+	for _, finding := range findings {
+		if finding.Kind == "markdown_image" {
+			confidence = 0.95
+			worst = finding
+			break
+		}
+	}
+	return &guard.ThreatResult{
+		IsThreat:   true,
+		ThreatType: "data_exfiltration",
+		Confidence: confidence,
+		Reasoning: "Output contains a " + worst.Kind + " to " + worst.Domain +
+			" (" + strings.Join(worst.Reasons, ", ") + ")",
+		Severity: guard.SeverityForType("data_exfiltration"),
+	}
+}
+
+// classify decides whether one URL is a reportable channel.
+func (s *Scanner) classify(kind, raw string) (Finding, bool) {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Hostname() == "" {
+		return Finding{}, false
+	}
+	domain := strings.ToLower(parsed.Hostname())
+	if s.allowed(domain) {
+		return Finding{}, false
+	}
+
+	finding := Finding{Kind: kind, URL: raw, Domain: domain,
+		Reasons: []string{"unlisted_domain"}}
+	if carriesPayload(parsed) {
+		finding.Reasons = append(finding.Reasons, "encoded_payload")
+	}
+	if kind == "markdown_image" {
+		// Images load without user interaction; the destination alone is
+		// enough to report.
+		finding.Reasons = append(finding.Reasons, "zero_click_channel")
+		return finding, true
+	}
+	// Links and bare URLs are only reported when they actually carry data.
+	return finding, len(finding.Reasons) > 1
+}
+
+func (s *Scanner) allowed(domain string) bool {
+	for _, allowed := range s.config.AllowedDomains {
+		allowed = strings.ToLower(allowed)
+		if domain == allowed || strings.HasSuffix(domain, "."+allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// carriesPayload reports whether the URL's path or query holds a segment
+// long and dense enough to be encoded data.
+func carriesPayload(parsed *url.URL) bool {
+	candidates := []string{parsed.RawQuery, parsed.Path, parsed.Fragment}
+	for _, candidate := range candidates {
+		for _, segment := range payloadSegment.FindAllString(candidate, -1) {
+			if entropy(segment) > 3.5 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// entropy is the Shannon entropy of a string in bits per byte.
+func entropy(text string) float64 {
+	if text == "" {
+		return 0
+	}
+	var counts [256]int
+	for i := 0; i < len(text); i++ {
+		counts[text[i]]++
+	}
+	total := float64(len(text))
+	sum := 0.0
+	for _, count := range counts {
+		if count == 0 {
+			continue
+		}
+		p := float64(count) / total
+		sum -= p * math.Log2(p)
+	}
+	return sum
+}
@@ -0,0 +1,127 @@
+// Package extauthz implements Envoy's external authorization protocol so
+// the detector can sit in the mesh: Envoy (or Istio) sends each request to
+// Check before forwarding it, and the verdict decides between forwarding
+// with x-guard-* headers injected and rejecting at the edge. One filter
+// config then enforces for every service behind the proxy.
+package extauthz
+
+import (
+	"context"
+	"fmt"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Detector is the detection entry point the authorizer needs; satisfied by
+// *detector.EnhancedDetector.
+type Detector interface {
+	DetectContext(ctx context.Context, text string) (*guard.ThreatResult, error)
+}
+
+// Server answers Envoy ext_authz checks with the detector's verdict.
+type Server struct {
+	detector Detector
+	policy   guard.Policy
+	// failOpen allows requests through when the detector errors; the
+	// default rejects them with 503 so an outage cannot be exploited.
+	failOpen bool
+}
+
+// NewServer wraps a detector for ext_authz serving. A nil policy uses
+// guard.DefaultPolicy.
+func NewServer(det Detector, policy *guard.Policy) *Server {
+	p := guard.DefaultPolicy()
+	if policy != nil {
+		p = *policy
+	}
+	return &Server{detector: det, policy: p}
+}
+
+// SetFailOpen makes detector errors allow instead of reject.
+func (s *Server) SetFailOpen(failOpen bool) {
+	s.failOpen = failOpen
+}
+
+// Register attaches the authorization service to a gRPC server.
+func (s *Server) Register(grpcServer *grpc.Server) {
+	authv3.RegisterAuthorizationServer(grpcServer, s)
+}
+
+// Check evaluates one proxied request. Requests without a body are allowed
+// untouched; Envoy must be configured with with_request_body for bodies to
+// arrive here.
+func (s *Server) Check(ctx context.Context, req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+	body := req.GetAttributes().GetRequest().GetHttp().GetBody()
+	if body == "" {
+		if raw := req.GetAttributes().GetRequest().GetHttp().GetRawBody(); len(raw) > 0 {
+			body = string(raw)
+		}
+	}
+	if body == "" {
+		return allowed(nil), nil
+	}
+
+	result, err := s.detector.DetectContext(ctx, body)
+	if err != nil {
+		if s.failOpen {
+			return allowed(nil), nil
+		}
+		return denied(typev3.StatusCode_ServiceUnavailable, "threat detection unavailable", nil), nil
+	}
+
+	action := s.policy.Evaluate(result)
+	if action == guard.ActionBlock || action == guard.ActionSoftBlock || action == guard.ActionQuarantine {
+		return denied(typev3.StatusCode_Forbidden, result.Reasoning, verdictHeaders(result, action)), nil
+	}
+	return allowed(verdictHeaders(result, action)), nil
+}
+
+// verdictHeaders carry the decision to the upstream service (on allow) or
+// back to the client (on deny).
+func verdictHeaders(result *guard.ThreatResult, action guard.Action) []*corev3.HeaderValueOption {
+	headers := []*corev3.HeaderValueOption{
+		header("x-guard-action", string(action)),
+	}
+	if result != nil && result.IsThreat {
+		headers = append(headers,
+			header("x-guard-threat-type", result.ThreatType),
+			header("x-guard-confidence", fmt.Sprintf("%.2f", result.Confidence)),
+		)
+	}
+	return headers
+}
+
+func header(key, value string) *corev3.HeaderValueOption {
+	return &corev3.HeaderValueOption{
+		Header: &corev3.HeaderValue{Key: key, Value: value},
+	}
+}
+
+func allowed(headers []*corev3.HeaderValueOption) *authv3.CheckResponse {
+	return &authv3.CheckResponse{
+		Status: &rpcstatus.Status{Code: int32(codes.OK)},
+		HttpResponse: &authv3.CheckResponse_OkResponse{
+			OkResponse: &authv3.OkHttpResponse{Headers: headers},
+		},
+	}
+}
+
+func denied(code typev3.StatusCode, message string, headers []*corev3.HeaderValueOption) *authv3.CheckResponse {
+	return &authv3.CheckResponse{
+		Status: &rpcstatus.Status{Code: int32(codes.PermissionDenied), Message: message},
+		HttpResponse: &authv3.CheckResponse_DeniedResponse{
+			DeniedResponse: &authv3.DeniedHttpResponse{
+				Status:  &typev3.HttpStatus{Code: code},
+				Headers: headers,
+				Body:    message,
+			},
+		},
+	}
+}
@@ -0,0 +1,76 @@
+// Package extract pulls the scannable regions out of HTML and Markdown.
+// Indirect injections hide instructions where a rendered page never shows
+// them — HTML comments, alt text, display:none elements, Markdown link
+// titles — so scanning only the visible text misses exactly the content
+// that matters. Extract returns each region separately, marked hidden or
+// visible, so the detector can judge concealed instructions on their own.
+package extract
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Region is one extracted piece of content.
+type Region struct {
+	// Kind says where the text came from: visible_text, html_comment,
+	// alt_text, title_attribute, hidden_element, link_title, or image_alt.
+	Kind string `json:"kind"`
+	Text string `json:"text"`
+	// Hidden is true for regions a rendered page would not display.
+	Hidden bool `json:"hidden"`
+}
+
+var (
+	htmlComment = regexp.MustCompile(`(?s)<!--(.*?)-->`)
+	altAttr     = regexp.MustCompile(`(?is)\balt\s*=\s*"([^"]*)"`)
+	titleAttr   = regexp.MustCompile(`(?is)\btitle\s*=\s*"([^"]*)"`)
+	// hiddenElement captures content inside elements styled invisible or
+	// carrying the hidden attribute.
+	hiddenElement = regexp.MustCompile(`(?is)<(\w+)[^>]*(?:style\s*=\s*"[^"]*(?:display\s*:\s*none|visibility\s*:\s*hidden|font-size\s*:\s*0)[^"]*"|\bhidden\b)[^>]*>(.*?)</`)
+	// mdLinkTitle captures the quoted title in [text](url "title") and
+	// mdImageAlt the alt text in ![alt](url).
+	mdLinkTitle = regexp.MustCompile(`\[[^\]]*\]\([^)\s]*\s+"([^"]*)"\)`)
+	mdImageAlt  = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	htmlTag     = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// looksLikeMarkup is a cheap gate so plain prompts skip extraction.
+func looksLikeMarkup(input string) bool {
+	return strings.Contains(input, "<") || strings.Contains(input, "](")
+}
+
+// Regions extracts the scannable regions of input. Plain text comes back
+// as a single visible region; markup additionally yields one region per
+// concealment surface that carries content.
+func Regions(input string) []Region {
+	if !looksLikeMarkup(input) {
+		return []Region{{Kind: "visible_text", Text: input}}
+	}
+
+	var regions []Region
+	add := func(kind string, hidden bool, matches [][]string, group int) {
+		for _, match := range matches {
+			text := strings.TrimSpace(match[group])
+			if text != "" {
+				regions = append(regions, Region{Kind: kind, Text: text, Hidden: hidden})
+			}
+		}
+	}
+
+	add("html_comment", true, htmlComment.FindAllStringSubmatch(input, -1), 1)
+	add("alt_text", true, altAttr.FindAllStringSubmatch(input, -1), 1)
+	add("title_attribute", true, titleAttr.FindAllStringSubmatch(input, -1), 1)
+	add("hidden_element", true, hiddenElement.FindAllStringSubmatch(input, -1), 2)
+	add("link_title", true, mdLinkTitle.FindAllStringSubmatch(input, -1), 1)
+	add("image_alt", true, mdImageAlt.FindAllStringSubmatch(input, -1), 1)
+
+	// Visible text: markup with comments and tags stripped.
+	visible := htmlComment.ReplaceAllString(input, " ")
+	visible = htmlTag.ReplaceAllString(visible, " ")
+	visible = strings.TrimSpace(visible)
+	if visible != "" {
+		regions = append(regions, Region{Kind: "visible_text", Text: visible})
+	}
+	return regions
+}
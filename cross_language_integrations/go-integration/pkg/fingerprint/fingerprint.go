@@ -0,0 +1,141 @@
+// Package fingerprint keeps a fuzzy fingerprint database of confirmed
+// malicious prompts. Attackers re-submit near-identical payloads with tiny
+// edits; a simhash lookup catches those variants cheaply instead of
+// re-paying the model cost each time.
+package fingerprint
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"sync"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// DefaultMaxDistance is the Hamming distance (out of 64 bits) at which two
+// simhashes are still considered the same prompt.
+const DefaultMaxDistance = 3
+
+// Entry is one confirmed malicious prompt in the store.
+type Entry struct {
+	Hash       uint64 `json:"hash"`
+	ThreatType string `json:"threat_type"`
+}
+
+// Store is an in-memory simhash database of known malicious prompts.
+type Store struct {
+	maxDistance int
+
+	mu      sync.RWMutex
+	entries []Entry
+}
+
+// NewStore creates an empty store matching at maxDistance Hamming bits
+// (0 uses DefaultMaxDistance).
+func NewStore(maxDistance int) *Store {
+	if maxDistance <= 0 {
+		maxDistance = DefaultMaxDistance
+	}
+	return &Store{maxDistance: maxDistance}
+}
+
+// Add fingerprints a confirmed malicious prompt under a threat type.
+func (s *Store) Add(prompt, threatType string) {
+	s.AddHash(Simhash(prompt), threatType)
+}
+
+// AddHash stores a precomputed fingerprint, e.g. one distributed by a
+// threat intelligence feed.
+func (s *Store) AddHash(hash uint64, threatType string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.entries {
+		if e.Hash == hash {
+			return
+		}
+	}
+	s.entries = append(s.entries, Entry{Hash: hash, ThreatType: threatType})
+}
+
+// Len returns the number of stored fingerprints.
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.entries)
+}
+
+// Entries returns a copy of the stored fingerprints.
+func (s *Store) Entries() []Entry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]Entry(nil), s.entries...)
+}
+
+// Lookup fingerprints text and returns a threat result when it is within
+// the store's Hamming distance of a known malicious prompt, nil otherwise.
+func (s *Store) Lookup(text string) *guard.ThreatResult {
+	hash := Simhash(text)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	bestDistance, bestIdx := s.maxDistance+1, -1
+	for i, e := range s.entries {
+		if d := bits.OnesCount64(hash ^ e.Hash); d < bestDistance {
+			bestDistance, bestIdx = d, i
+		}
+	}
+	if bestIdx < 0 {
+		return nil
+	}
+	matched := s.entries[bestIdx]
+	// Exact hits are certain; confidence tapers off with edit distance.
+	confidence := 0.95 - 0.05*float64(bestDistance)
+	return &guard.ThreatResult{
+		IsThreat:   true,
+		ThreatType: matched.ThreatType,
+		Confidence: confidence,
+		Reasoning:  fmt.Sprintf("Fingerprint match: %d bits from known malicious prompt", bestDistance),
+		Severity:   guard.SeverityForType(matched.ThreatType),
+	}
+}
+
+// Simhash computes a 64-bit simhash over word trigrams of the normalized
+// text, so small edits flip only a few bits.
+func Simhash(text string) uint64 {
+	words := strings.Fields(strings.ToLower(guard.Normalize(text).Text))
+	var weights [64]int
+	for _, shingle := range shingles(words, 3) {
+		h := fnv.New64a()
+		h.Write([]byte(shingle))
+		hash := h.Sum64()
+		for bit := 0; bit < 64; bit++ {
+			if hash&(1<<uint(bit)) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+	var hash uint64
+	for bit := 0; bit < 64; bit++ {
+		if weights[bit] > 0 {
+			hash |= 1 << uint(bit)
+		}
+	}
+	return hash
+}
+
+func shingles(words []string, n int) []string {
+	if len(words) == 0 {
+		return nil
+	}
+	if len(words) < n {
+		return []string{strings.Join(words, " ")}
+	}
+	out := make([]string, 0, len(words)-n+1)
+	for i := 0; i+n <= len(words); i++ {
+		out = append(out, strings.Join(words[i:i+n], " "))
+	}
+	return out
+}
@@ -0,0 +1,49 @@
+package guard
+
+// AllowTrace explains why a benign verdict passed: which stages ran, the
+// per-category regex scores, and the margin to the nearest block threshold.
+// False-negative investigations use it to see exactly how close an input
+// came to being flagged.
+type AllowTrace struct {
+	// Stages lists the pipeline stages that ran, in order.
+	Stages []string `json:"stages"`
+	// RegexScores is the per-category score vector from the regex stage.
+	RegexScores map[string]float64 `json:"regex_scores,omitempty"`
+	// ModelConfidence is the model's confidence in its benign verdict,
+	// when the model stage ran.
+	ModelConfidence float64 `json:"model_confidence,omitempty"`
+	// NearestType is the category that came closest to its threshold.
+	NearestType string `json:"nearest_type,omitempty"`
+	// NearestScore and NearestThreshold give that category's score and the
+	// confidence it would have needed to trigger; Margin is the difference.
+	NearestScore     float64 `json:"nearest_score,omitempty"`
+	NearestThreshold float64 `json:"nearest_threshold,omitempty"`
+	Margin           float64 `json:"margin,omitempty"`
+}
+
+// BuildAllowTrace computes the trace for a benign verdict on text using the
+// policy's thresholds.
+func BuildAllowTrace(text string, stages []string, modelConfidence float64, policy Policy) *AllowTrace {
+	trace := &AllowTrace{
+		Stages:          stages,
+		RegexScores:     ScoreRegex(text),
+		ModelConfidence: modelConfidence,
+	}
+
+	bestMargin := 0.0
+	for tType, score := range trace.RegexScores {
+		rule, ok := policy.Rules[tType]
+		if !ok {
+			rule = policy.DefaultRule
+		}
+		margin := rule.Threshold - score
+		if trace.NearestType == "" || margin < bestMargin {
+			trace.NearestType = tType
+			trace.NearestScore = score
+			trace.NearestThreshold = rule.Threshold
+			trace.Margin = margin
+			bestMargin = margin
+		}
+	}
+	return trace
+}
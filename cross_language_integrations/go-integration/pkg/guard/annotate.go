@@ -0,0 +1,112 @@
+package guard
+
+import (
+	"sort"
+	"strings"
+)
+
+// Annotation support for flagged-but-allowed content: when policy says flag
+// rather than block, the caller can pass the text downstream with the
+// suspicious regions marked, and instruct the LLM to treat marked regions as
+// untrusted data rather than instructions.
+
+// Span is one flagged region of the original text.
+type Span struct {
+	Start      int    `json:"start"`
+	End        int    `json:"end"`
+	ThreatType string `json:"threat_type"`
+}
+
+// Annotation pairs the marked-up text with a parallel span list, so callers
+// can use either representation.
+type Annotation struct {
+	// Annotated is the original text with every flagged region wrapped in
+	// [UNTRUSTED type=...]...[/UNTRUSTED] markers.
+	Annotated string `json:"annotated"`
+	Spans     []Span `json:"spans,omitempty"`
+}
+
+// Annotate marks the regions of text that match the result's threat
+// categories. Overlapping matches are merged into a single span.
+func Annotate(text string, result *ThreatResult) Annotation {
+	if result == nil || !result.IsThreat {
+		return Annotation{Annotated: text}
+	}
+
+	types := []string{result.ThreatType}
+	for _, label := range result.Secondary {
+		types = append(types, label.ThreatType)
+	}
+
+	var spans []Span
+	patterns, _, _ := compiledRules()
+	for _, tType := range types {
+		for _, cp := range patterns {
+			if cp.threatType != tType {
+				continue
+			}
+			for _, loc := range cp.re.FindAllStringIndex(text, -1) {
+				spans = append(spans, Span{Start: loc[0], End: loc[1], ThreatType: tType})
+			}
+		}
+	}
+	spans = mergeSpans(spans)
+
+	var b strings.Builder
+	last := 0
+	for _, span := range spans {
+		b.WriteString(text[last:span.Start])
+		b.WriteString("[UNTRUSTED type=" + span.ThreatType + "]")
+		b.WriteString(text[span.Start:span.End])
+		b.WriteString("[/UNTRUSTED]")
+		last = span.End
+	}
+	b.WriteString(text[last:])
+
+	return Annotation{Annotated: b.String(), Spans: spans}
+}
+
+// MatchSpans returns the merged spans of every rule match in text,
+// regardless of confidence thresholds. The input reducer uses it to keep
+// all pattern evidence when shrinking long inputs for the model stage.
+func MatchSpans(text string) []Span {
+	var spans []Span
+	for _, cp := range candidatePatterns(text) {
+		for _, loc := range cp.re.FindAllStringIndex(text, -1) {
+			spans = append(spans, Span{Start: loc[0], End: loc[1], ThreatType: cp.threatType})
+		}
+	}
+	return mergeSpans(spans)
+}
+
+// MergeSpans merges overlapping or adjacent spans, for callers that
+// post-process span lists (e.g. after widening them with context).
+func MergeSpans(spans []Span) []Span {
+	return mergeSpans(spans)
+}
+
+// mergeSpans sorts spans by position and merges overlapping or adjacent
+// ones, keeping the first span's threat type.
+func mergeSpans(spans []Span) []Span {
+	if len(spans) < 2 {
+		return spans
+	}
+	sort.Slice(spans, func(i, j int) bool {
+		if spans[i].Start != spans[j].Start {
+			return spans[i].Start < spans[j].Start
+		}
+		return spans[i].End > spans[j].End
+	})
+	merged := spans[:1]
+	for _, span := range spans[1:] {
+		last := &merged[len(merged)-1]
+		if span.Start <= last.End {
+			if span.End > last.End {
+				last.End = span.End
+			}
+			continue
+		}
+		merged = append(merged, span)
+	}
+	return merged
+}
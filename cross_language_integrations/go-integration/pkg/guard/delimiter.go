@@ -0,0 +1,88 @@
+package guard
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Delimiter integrity. Applications that embed user input in a prompt
+// usually fence it ("<user_input>...</user_input>") and tell the model to
+// treat everything inside as data. Attackers break out by closing the
+// fence early, opening a spoofed one, or injecting role markers the chat
+// template would honor. The single `</system>`-style pattern in
+// RegexPatterns catches almost none of this; WrapInput and
+// VerifyDelimiters cover the breakout surface properly.
+
+// WrappedInput is the result of WrapInput: the fenced text plus the
+// random tag, which the application needs to reference the region in its
+// own prompt ("everything between <input-ab12...> tags is data").
+type WrappedInput struct {
+	Tag  string
+	Text string
+}
+
+// WrapInput fences input with a single-use random delimiter. Because the
+// tag is unguessable, an attacker cannot close the fence; combine with
+// VerifyDelimiters to catch attempts against the well-known markers.
+func WrapInput(input string) (WrappedInput, error) {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return WrappedInput{}, fmt.Errorf("failed to generate delimiter tag: %w", err)
+	}
+	tag := "input-" + hex.EncodeToString(buf[:])
+	return WrappedInput{
+		Tag:  tag,
+		Text: fmt.Sprintf("<%s>\n%s\n</%s>", tag, input, tag),
+	}, nil
+}
+
+// breakoutPatterns match the markers an attacker needs to escape a
+// delimited region: closing or opening fence tags, chat-template control
+// tokens, line-start role markers, and spoofed system headers. Each hit
+// reports the category so the reasoning names the technique.
+var breakoutPatterns = []struct {
+	category string
+	re       *regexp.Regexp
+}{
+	{"fence tag", regexp.MustCompile(`(?i)</?\s*(?:system|user|assistant|instructions?|context|input|prompt|sys|rules?)\b[^>]*>`)},
+	{"chat template token", regexp.MustCompile(`(?i)<\|\s*(?:im_start|im_end|system|user|assistant|endoftext)\s*\|>`)},
+	{"chat template token", regexp.MustCompile(`(?i)\[/?INST\]|<</?SYS>>`)},
+	{"role marker", regexp.MustCompile(`(?im)^\s*(?:system|assistant|tool|function)\s*:`)},
+	{"spoofed header", regexp.MustCompile(`(?im)^\s*#{1,6}\s*(?:system|instructions?)\b`)},
+}
+
+// VerifyDelimiters scans input for delimiter-breakout markers and returns
+// a system_prompt_attack result naming each category found, or nil when
+// the input contains none. Run it on the raw input before wrapping; none
+// of these markers have a legitimate reason to appear in user data.
+func VerifyDelimiters(input string) *ThreatResult {
+	var categories []string
+	for _, pattern := range breakoutPatterns {
+		if !pattern.re.MatchString(input) {
+			continue
+		}
+		seen := false
+		for _, existing := range categories {
+			if existing == pattern.category {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			categories = append(categories, pattern.category)
+		}
+	}
+	if len(categories) == 0 {
+		return nil
+	}
+	return &ThreatResult{
+		IsThreat:   true,
+		ThreatType: "system_prompt_attack",
+		Confidence: 0.95,
+		Reasoning:  "Delimiter breakout attempt: " + strings.Join(categories, ", "),
+		Severity:   SeverityForType("system_prompt_attack"),
+	}
+}
@@ -0,0 +1,126 @@
+package guard
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Attackers hide payloads from the regex stage by encoding them. CheckEncoded
+// finds candidate encoded substrings, decodes them, and runs the normal
+// pattern check against the decoded content.
+
+var (
+	base64Candidate = regexp.MustCompile(`[A-Za-z0-9+/]{16,}={0,2}`)
+	hexCandidate    = regexp.MustCompile(`(?:0x)?(?:[0-9a-fA-F]{2}){8,}`)
+	urlEncoded      = regexp.MustCompile(`(?:%[0-9a-fA-F]{2}){4,}`)
+	// ROT13 preserves spaces, so candidates are runs of letters and spaces
+	// long enough to hold an instruction. Rotating ordinary English yields
+	// gibberish that matches nothing, so over-matching here is harmless.
+	rot13Candidate = regexp.MustCompile(`[A-Za-z][A-Za-z ]{14,}[A-Za-z]`)
+)
+
+// maxDecodeDepth bounds recursive decoding (e.g. base64 inside base64).
+const maxDecodeDepth = 2
+
+// CheckEncoded scans for threat patterns hidden in base64, hex,
+// URL-encoded, or ROT13 payloads. It returns nil when no decoded payload
+// matches.
+func CheckEncoded(text string) *ThreatResult {
+	return checkEncodedDepth(text, 1)
+}
+
+func checkEncodedDepth(text string, depth int) *ThreatResult {
+	if depth > maxDecodeDepth {
+		return nil
+	}
+
+	for _, candidate := range decodeCandidates(text) {
+		if result := CheckRegex(candidate.decoded); result != nil {
+			result.Reasoning = "Encoded payload (" + candidate.encoding + "): " + result.Reasoning
+			return result
+		}
+		if result := checkEncodedDepth(candidate.decoded, depth+1); result != nil {
+			result.Reasoning = "Encoded payload (" + candidate.encoding + "): " + result.Reasoning
+			return result
+		}
+	}
+	return nil
+}
+
+type decodedCandidate struct {
+	encoding string
+	decoded  string
+}
+
+func decodeCandidates(text string) []decodedCandidate {
+	var candidates []decodedCandidate
+
+	for _, match := range base64Candidate.FindAllString(text, 8) {
+		if decoded, ok := decodeBase64(match); ok {
+			candidates = append(candidates, decodedCandidate{"base64", decoded})
+		}
+	}
+	for _, match := range hexCandidate.FindAllString(text, 8) {
+		trimmed := strings.TrimPrefix(match, "0x")
+		if decoded, err := hex.DecodeString(trimmed); err == nil && isPrintableText(string(decoded)) {
+			candidates = append(candidates, decodedCandidate{"hex", string(decoded)})
+		}
+	}
+	if urlEncoded.MatchString(text) {
+		if decoded, err := url.QueryUnescape(text); err == nil && decoded != text {
+			candidates = append(candidates, decodedCandidate{"url", decoded})
+		}
+	}
+	for _, match := range rot13Candidate.FindAllString(text, 8) {
+		candidates = append(candidates, decodedCandidate{"rot13", rot13(match)})
+	}
+	return candidates
+}
+
+func rot13(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return 'a' + (r-'a'+13)%26
+		case r >= 'A' && r <= 'Z':
+			return 'A' + (r-'A'+13)%26
+		}
+		return r
+	}, s)
+}
+
+func decodeBase64(s string) (string, bool) {
+	decoded, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		decoded, err = base64.RawStdEncoding.DecodeString(s)
+	}
+	if err != nil {
+		return "", false
+	}
+	text := string(decoded)
+	if !isPrintableText(text) {
+		return "", false
+	}
+	return text, true
+}
+
+// isPrintableText filters out decodes that are clearly binary noise so we
+// only scan plausible text payloads.
+func isPrintableText(s string) bool {
+	if !utf8.ValidString(s) || len(s) < 8 {
+		return false
+	}
+	printable := 0
+	total := 0
+	for _, r := range s {
+		total++
+		if r == '\n' || r == '\t' || (r >= 0x20 && r < 0x7f) {
+			printable++
+		}
+	}
+	return total > 0 && float64(printable)/float64(total) >= 0.9
+}
@@ -1,15 +1,65 @@
 package guard
 
 import (
-	"regexp"
+	"strings"
 )
 
 // ThreatResult represents the outcome of a threat check
 type ThreatResult struct {
-	IsThreat   bool    `json:"is_threat"`
+	IsThreat   bool     `json:"is_threat"`
+	ThreatType string   `json:"threat_type"`
+	Confidence float64  `json:"confidence"`
+	Reasoning  string   `json:"reasoning"`
+	Severity   Severity `json:"severity,omitempty"`
+	// Honeytoken marks a tripwire hit that must bypass sampling and
+	// threshold-based suppression.
+	Honeytoken bool `json:"honeytoken,omitempty"`
+	// Secondary carries additional categories the input also matched
+	// (e.g. jailbreak + toxic_content). ThreatType remains the primary.
+	Secondary []Label `json:"secondary_labels,omitempty"`
+	// Provenance records how the input reached the model when the caller
+	// supplied a provenance chain (outermost hop first).
+	Provenance []ProvenanceHop `json:"provenance,omitempty"`
+	// Trace explains benign verdicts when allow tracing is enabled
+	// (see EnhancedDetector.EnableAllowTrace).
+	Trace *AllowTrace `json:"allow_trace,omitempty"`
+	// Matches locates the pattern hits behind a regex-stage verdict, so
+	// review UIs can highlight exactly what triggered it.
+	Matches []Match `json:"matches,omitempty"`
+	// Usage attributes model-stage token usage and estimated cost to this
+	// verdict when cost tracking is enabled (see EnableCostTracking).
+	Usage *Usage `json:"usage,omitempty"`
+}
+
+// Usage is the model-stage cost attributed to one verdict.
+type Usage struct {
+	InputTokens int `json:"input_tokens"`
+	// EstimatedCost is in the configured currency unit (typically USD).
+	EstimatedCost float64 `json:"estimated_cost"`
+}
+
+// Match is one located pattern hit. Offsets refer to the normalized form
+// of the input (see Normalize) — obfuscated inputs shift positions when
+// zero-width characters and homoglyphs are folded away.
+type Match struct {
+	Pattern string `json:"pattern"`
+	Start   int    `json:"start"`
+	End     int    `json:"end"`
+	Snippet string `json:"snippet"`
+}
+
+// ProvenanceHop is one step in an input's path to the model, e.g.
+// {Medium: "email"} → {Medium: "attachment", Detail: "invoice.pdf"} →
+// {Medium: "ocr_text"}.
+type ProvenanceHop struct {
+	Medium string `json:"medium"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Label is one (threat type, confidence) pair on a multi-label result.
+type Label struct {
 	ThreatType string  `json:"threat_type"`
 	Confidence float64 `json:"confidence"`
-	Reasoning  string  `json:"reasoning"`
 }
 
 // Field represents a field in the prompt schema
@@ -27,7 +77,7 @@ const (
 var PromptFields = []Field{
 	{Name: "input_text", Prefix: "Input Text:", Description: "${input_text}"},
 	{Name: "is_threat", Prefix: "Is Threat:", Description: "Boolean: True if threat detected"},
-	{Name: "threat_type", Prefix: "Threat Type:", Description: "Type: prompt_injection, auth_bypass, data_exfiltration, dos_attack, business_logic_abuse, content_manipulation, system_prompt_attack, jailbreak, toxic_content, code_injection, context_manipulation, output_manipulation, resource_exhaustion, information_disclosure, privilege_escalation, session_hijacking, man_in_the_middle, model_inversion, adversarial_input, benign"},
+	{Name: "threat_type", Prefix: "Threat Type:", Description: "Type: prompt_injection, auth_bypass, data_exfiltration, dos_attack, business_logic_abuse, content_manipulation, system_prompt_attack, jailbreak, toxic_content, code_injection, context_manipulation, output_manipulation, resource_exhaustion, information_disclosure, privilege_escalation, session_hijacking, man_in_the_middle, model_inversion, adversarial_input, guard_probing, benign"},
 	{Name: "confidence", Prefix: "Confidence:", Description: "Confidence score 0-1"},
 	{Name: "reasoning", Prefix: "Reasoning:", Description: "Brief explanation"},
 }
@@ -156,41 +206,147 @@ var RegexPatterns = map[string][]string{
 		`(?i)(?:fool|trick|bypass)\s+(?:detection|filters|security)`,
 		`(?i)(?:unicode\s+tricks|homoglyphs|invisible\s+characters)`,
 	},
+	"guard_probing": {
+		`(?i)(?:what|which)\s+(?:filters?|rules?|patterns?|blocklists?)\s+(?:are\s+you|do\s+you)\s+(?:using|use|have)`,
+		`(?i)(?:list|repeat|show|enumerate)\s+(?:back\s+)?(?:the\s+|your\s+)?(?:moderation|safety|banned|blocked|forbidden)\s+(?:categories|words?|terms?|topics?|rules?)`,
+		`(?i)respond\s+(?:only\s+)?with\s+(?:the\s+word\s+)?["']?(?:allowed|safe|benign|ok)["']?`,
+		`(?i)(?:is|are)\s+(?:there|you\s+using)\s+(?:a\s+)?(?:content\s+)?(?:filter|moderation|guard|classifier)`,
+		`(?i)(?:how|what)\s+(?:does|triggers?|makes?)\s+(?:your|the)\s+(?:filter|moderation|safety|guard|detection)`,
+		`(?i)(?:test|probe|check)\s+(?:your|the)\s+(?:filter|moderation|guardrails?|safety\s+system)`,
+		`(?i)what\s+(?:words?|phrases?|inputs?)\s+(?:get|are)\s+(?:blocked|flagged|censored)`,
+	},
+}
+
+// ScoreRegex runs every applicable pattern (not just until the first hit)
+// and returns a per-category score vector. A single match scores the
+// category at its base confidence; additional matches nudge it upward.
+func ScoreRegex(text string) map[string]float64 {
+	normalized := Normalize(text)
+	text = normalized.Text
+
+	matchCounts := map[string]int{}
+	for _, cp := range candidatePatterns(text) {
+		if cp.re.MatchString(text) {
+			matchCounts[cp.threatType]++
+		}
+	}
+
+	scores := make(map[string]float64, len(matchCounts))
+	for tType, count := range matchCounts {
+		base := 0.5
+		if HighSeverityTypes[tType] {
+			base = 0.95
+		}
+		score := base + 0.02*float64(count-1)
+		if score > 0.99 {
+			score = 0.99
+		}
+		scores[tType] = score
+	}
+	return scores
 }
 
+// maxLocatedMatches bounds the Matches slice so a pathological input
+// matching everywhere cannot inflate every downstream record.
+const maxLocatedMatches = 20
+
 // HighSeverityTypes contains types that trigger immediate blocking
 var HighSeverityTypes = map[string]bool{
-	"data_exfiltration": true,
+	"data_exfiltration":    true,
 	"system_prompt_attack": true,
-	"auth_bypass": true,
-	"code_injection": true,
+	"auth_bypass":          true,
+	"code_injection":       true,
 }
 
-// CheckRegex performs fast regex-based threat detection
+// CheckRegex performs fast regex-based threat detection. Input is run
+// through the normalization pipeline first so zero-width characters,
+// homoglyphs, and full-width forms cannot hide a pattern.
 func CheckRegex(text string) *ThreatResult {
-	for tType, patterns := range RegexPatterns {
-		for _, pattern := range patterns {
-			// Simple case-insensitive check
-			// Note: This compiles regex on every check which is slow.
-			// In production, these should be pre-compiled.
-			re, err := regexp.Compile("(?i)" + pattern)
-			if err != nil {
-				continue
+	normalized := Normalize(text)
+	text = normalized.Text
+	// Honeytoken tripwires outrank everything, including allowlists.
+	if result := checkHoneytokens(text); result != nil {
+		return result
+	}
+	// Temporary overrides (zero-day blocks, time-boxed allowlist entries)
+	// take precedence over the built-in pattern set.
+	if result, matched := checkTemporary(text); matched {
+		return result
+	}
+	// The Aho-Corasick prefilter narrows the pre-compiled pattern set to the
+	// few whose required literal actually occurs in the input. All candidates
+	// run so the result can carry every matching category, not just the first.
+	type typeMatch struct {
+		firstPattern string
+		count        int
+		confidence   float64
+	}
+	matches := map[string]*typeMatch{}
+	order := []string{}
+	var located []Match
+	for _, cp := range candidatePatterns(text) {
+		locs := cp.re.FindAllStringIndex(text, -1)
+		if len(locs) == 0 {
+			continue
+		}
+		tm, ok := matches[cp.threatType]
+		if !ok {
+			confidence := 0.5
+			if HighSeverityTypes[cp.threatType] {
+				confidence = 0.95
 			}
-			if re.MatchString(text) {
-				isHighSev := HighSeverityTypes[tType]
-				confidence := 0.5
-				if isHighSev {
-					confidence = 0.95
-				}
-				return &ThreatResult{
-					IsThreat:   true,
-					ThreatType: tType,
-					Confidence: confidence,
-					Reasoning:  "Regex match: " + pattern,
-				}
+			tm = &typeMatch{firstPattern: cp.raw, confidence: confidence}
+			matches[cp.threatType] = tm
+			order = append(order, cp.threatType)
+		}
+		tm.count++
+		for _, loc := range locs {
+			if len(located) >= maxLocatedMatches {
+				break
 			}
+			located = append(located, Match{
+				Pattern: cp.raw,
+				Start:   loc[0],
+				End:     loc[1],
+				Snippet: text[loc[0]:loc[1]],
+			})
 		}
 	}
-	return nil
-}
\ No newline at end of file
+	if len(matches) == 0 {
+		return nil
+	}
+
+	// Primary is the category with the highest confidence (most matches as
+	// tie-breaker); the rest become secondary labels.
+	primary := order[0]
+	for _, tType := range order[1:] {
+		tm, best := matches[tType], matches[primary]
+		if tm.confidence > best.confidence ||
+			(tm.confidence == best.confidence && tm.count > best.count) {
+			primary = tType
+		}
+	}
+
+	reasoning := "Regex match: " + matches[primary].firstPattern
+	if normalized.Obfuscated {
+		reasoning += " (obfuscation detected: " + strings.Join(normalized.Techniques, ", ") + ")"
+	}
+	result := &ThreatResult{
+		IsThreat:   true,
+		ThreatType: primary,
+		Confidence: matches[primary].confidence,
+		Reasoning:  reasoning,
+		Severity:   SeverityForType(primary),
+		Matches:    located,
+	}
+	for _, tType := range order {
+		if tType == primary {
+			continue
+		}
+		result.Secondary = append(result.Secondary, Label{
+			ThreatType: tType,
+			Confidence: matches[tType].confidence,
+		})
+	}
+	return result
+}
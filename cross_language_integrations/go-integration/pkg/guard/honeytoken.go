@@ -0,0 +1,89 @@
+package guard
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// Honeytokens are strings that only an attacker with access to leaked
+// internal material (docs, system prompts, credentials) would ever send.
+// Any hit is treated as a confirmed compromise: severity is forced to
+// critical, sampling must not skip it, and the hit callback fires
+// regardless of policy thresholds.
+
+// Honeytoken is a registered tripwire rule.
+type Honeytoken struct {
+	ID          string `json:"id"`
+	Pattern     string `json:"pattern"`
+	Description string `json:"description"`
+
+	re *regexp.Regexp
+}
+
+var (
+	honeytokenMu sync.RWMutex
+	honeytokens  = map[string]*Honeytoken{}
+
+	// OnHoneytokenHit, when set, is invoked synchronously for every
+	// honeytoken hit (e.g. by the webhook notifier). It runs before the
+	// result is returned to the caller.
+	OnHoneytokenHit func(token Honeytoken, result *ThreatResult)
+)
+
+// RegisterHoneytoken adds a tripwire pattern. Matching is case-sensitive by
+// default since honeytokens are exact planted strings; wrap the pattern in
+// (?i) if needed.
+func RegisterHoneytoken(token Honeytoken) error {
+	if token.ID == "" {
+		return fmt.Errorf("honeytoken needs an ID")
+	}
+	re, err := regexp.Compile(token.Pattern)
+	if err != nil {
+		return fmt.Errorf("honeytoken %q has invalid pattern: %w", token.ID, err)
+	}
+	token.re = re
+
+	honeytokenMu.Lock()
+	defer honeytokenMu.Unlock()
+	honeytokens[token.ID] = &token
+	return nil
+}
+
+// UnregisterHoneytoken removes a tripwire. It reports whether it existed.
+func UnregisterHoneytoken(id string) bool {
+	honeytokenMu.Lock()
+	defer honeytokenMu.Unlock()
+	_, ok := honeytokens[id]
+	delete(honeytokens, id)
+	return ok
+}
+
+// checkHoneytokens returns a critical result for the first tripwire hit.
+func checkHoneytokens(text string) *ThreatResult {
+	honeytokenMu.RLock()
+	var hit *Honeytoken
+	for _, token := range honeytokens {
+		if token.re.MatchString(text) {
+			hit = token
+			break
+		}
+	}
+	honeytokenMu.RUnlock()
+	if hit == nil {
+		return nil
+	}
+
+	result := &ThreatResult{
+		IsThreat:   true,
+		ThreatType: "data_exfiltration",
+		Confidence: 1.0,
+		Reasoning:  fmt.Sprintf("Honeytoken %q hit: %s", hit.ID, hit.Description),
+		Severity:   SeverityCritical,
+		Honeytoken: true,
+	}
+	if OnHoneytokenHit != nil {
+		OnHoneytokenHit(*hit, result)
+	}
+	return result
+}
@@ -0,0 +1,55 @@
+package guard
+
+import (
+	"sync"
+	"time"
+)
+
+// Lockdown is the emergency switch for active incidents. While enabled:
+//   - every detected threat is blocked regardless of policy thresholds
+//   - allowlist entries (policy AllowTypes and temporary allows) are ignored
+//   - the detector fails closed when the model backend is unavailable
+//
+// State lives in-process; deployments with multiple replicas propagate it by
+// calling the admin endpoint on each instance (or restarting with
+// -lockdown via shared config).
+
+// LockdownStatus reports the current lockdown state.
+type LockdownStatus struct {
+	Enabled bool      `json:"enabled"`
+	Reason  string    `json:"reason,omitempty"`
+	Since   time.Time `json:"since,omitempty"`
+}
+
+var (
+	lockdownMu    sync.RWMutex
+	lockdownState LockdownStatus
+)
+
+// EnableLockdown switches the guard to its strictest posture.
+func EnableLockdown(reason string) {
+	lockdownMu.Lock()
+	defer lockdownMu.Unlock()
+	lockdownState = LockdownStatus{Enabled: true, Reason: reason, Since: time.Now()}
+}
+
+// DisableLockdown returns the guard to normal policy evaluation.
+func DisableLockdown() {
+	lockdownMu.Lock()
+	defer lockdownMu.Unlock()
+	lockdownState = LockdownStatus{}
+}
+
+// InLockdown reports whether lockdown mode is active.
+func InLockdown() bool {
+	lockdownMu.RLock()
+	defer lockdownMu.RUnlock()
+	return lockdownState.Enabled
+}
+
+// Lockdown returns the full lockdown status for admin APIs.
+func Lockdown() LockdownStatus {
+	lockdownMu.RLock()
+	defer lockdownMu.RUnlock()
+	return lockdownState
+}
@@ -0,0 +1,155 @@
+package guard
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// zeroWidthRunes are invisible characters attackers insert to split words
+// so that patterns like "ignore previous instructions" no longer match.
+var zeroWidthRunes = map[rune]bool{
+	'\u200b': true, // zero width space
+	'\u200c': true, // zero width non-joiner
+	'\u200d': true, // zero width joiner
+	'\u2060': true, // word joiner
+	'\ufeff': true, // zero width no-break space / BOM
+	'\u00ad': true, // soft hyphen
+	'\u180e': true, // mongolian vowel separator
+}
+
+// homoglyphs folds common non-Latin lookalikes (mostly Cyrillic and Greek)
+// to their ASCII equivalents. NFKC does not cover these because they are
+// distinct letters, not compatibility forms.
+var homoglyphs = map[rune]rune{
+	'а': 'a', 'е': 'e', 'о': 'o', 'р': 'p', 'с': 'c', 'х': 'x', // Cyrillic
+	'і': 'i', 'ѕ': 's', 'у': 'y', 'ԁ': 'd', 'ɡ': 'g', 'ո': 'n',
+	'А': 'A', 'В': 'B', 'Е': 'E', 'К': 'K', 'М': 'M', 'Н': 'H',
+	'О': 'O', 'Р': 'P', 'С': 'C', 'Т': 'T', 'Х': 'X',
+	'α': 'a', 'β': 'b', 'ε': 'e', 'ι': 'i', 'κ': 'k', 'ν': 'v', // Greek
+	'ο': 'o', 'ρ': 'p', 'τ': 't', 'υ': 'u',
+	'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'Ι': 'I',
+	'Κ': 'K', 'Μ': 'M', 'Ν': 'N', 'Ο': 'O', 'Ρ': 'P', 'Τ': 'T',
+}
+
+// leetSubstitutions folds digit and symbol stand-ins for letters (1gn0re,
+// p@ssword). A substitution only applies when the character sits inside a
+// word — "2 for 1" keeps its digits.
+var leetSubstitutions = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't',
+	'@': 'a', '$': 's', '¡': 'i',
+}
+
+// separatedLetters matches words spelled out one letter at a time with a
+// separator between them (i-g-n-o-r-e, i.g.n.o.r.e); spacedLetters matches
+// the space-separated variant. Both require at least four letters so
+// ordinary hyphenation and initials survive.
+var (
+	separatedLetters = regexp.MustCompile(`(?:\pL[-._*]){3,}\pL`)
+	spacedLetters    = regexp.MustCompile(`(?:\b\pL +){3,}\pL\b`)
+)
+
+// NormalizationResult describes what the normalization pipeline changed.
+type NormalizationResult struct {
+	// Text is the normalized input to run detection against.
+	Text string
+	// Obfuscated is true when the input used tricks (zero-width characters,
+	// homoglyphs, compatibility forms) that changed under normalization.
+	Obfuscated bool
+	// Techniques lists the obfuscation techniques that were detected.
+	Techniques []string
+}
+
+// Normalize runs the full preprocessing pipeline: NFKC normalization
+// (full-width forms, ligatures), zero-width stripping, homoglyph folding,
+// and whitespace collapsing. Detection should always run on the returned
+// text so trivial Unicode tricks cannot bypass the regex stage.
+func Normalize(text string) NormalizationResult {
+	result := NormalizationResult{}
+
+	// NFKC folds full-width characters, ligatures, and other compatibility
+	// forms into their canonical equivalents.
+	nfkc := norm.NFKC.String(text)
+	if nfkc != text {
+		result.Techniques = append(result.Techniques, "unicode_compatibility_forms")
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(nfkc))
+	strippedZeroWidth := false
+	foldedHomoglyph := false
+	for _, r := range nfkc {
+		if zeroWidthRunes[r] {
+			strippedZeroWidth = true
+			continue
+		}
+		if folded, ok := homoglyphs[r]; ok {
+			foldedHomoglyph = true
+			r = folded
+		}
+		sb.WriteRune(r)
+	}
+	if strippedZeroWidth {
+		result.Techniques = append(result.Techniques, "zero_width_characters")
+	}
+	if foldedHomoglyph {
+		result.Techniques = append(result.Techniques, "homoglyphs")
+	}
+
+	text = sb.String()
+
+	// Fold leetspeak substitutions inside words.
+	if folded, changed := foldSubstitutions(text); changed {
+		text = folded
+		result.Techniques = append(result.Techniques, "character_substitution")
+	}
+
+	// Rejoin words spelled out with separators or spaces between letters.
+	joined := separatedLetters.ReplaceAllStringFunc(text, stripNonLetters)
+	joined = spacedLetters.ReplaceAllStringFunc(joined, stripNonLetters)
+	if joined != text {
+		text = joined
+		result.Techniques = append(result.Techniques, "separator_insertion")
+	}
+
+	// Collapse runs of whitespace so padding cannot break word-boundary
+	// patterns. This is not reported as obfuscation on its own.
+	result.Text = strings.Join(strings.Fields(text), " ")
+	result.Obfuscated = len(result.Techniques) > 0
+	return result
+}
+
+// foldSubstitutions replaces leet characters that have a letter neighbor.
+func foldSubstitutions(text string) (string, bool) {
+	runes := []rune(text)
+	changed := false
+	for i, r := range runes {
+		sub, ok := leetSubstitutions[r]
+		if !ok {
+			continue
+		}
+		prevLetter := i > 0 && unicode.IsLetter(runes[i-1])
+		nextLetter := i+1 < len(runes) && unicode.IsLetter(runes[i+1])
+		if prevLetter || nextLetter {
+			runes[i] = sub
+			changed = true
+		}
+	}
+	if !changed {
+		return text, false
+	}
+	return string(runes), true
+}
+
+// stripNonLetters removes the separator characters from a spelled-out word.
+func stripNonLetters(match string) string {
+	var sb strings.Builder
+	for _, r := range match {
+		if unicode.IsLetter(r) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,139 @@
+package guard
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// Temporary rules let operators react immediately (e.g. block a zero-day
+// payload for 72 hours) while a proper rule is authored. Entries expire
+// automatically and leave an audit record when they do.
+
+// TemporaryRule is a time-boxed block or allowlist entry.
+type TemporaryRule struct {
+	ID         string `json:"id"`
+	Pattern    string `json:"pattern"`
+	ThreatType string `json:"threat_type"`
+	// Allow makes this an allowlist entry: matching inputs skip detection
+	// instead of being blocked.
+	Allow     bool      `json:"allow"`
+	Reason    string    `json:"reason"`
+	AddedBy   string    `json:"added_by"`
+	ExpiresAt time.Time `json:"expires_at"`
+
+	re *regexp.Regexp
+}
+
+// ExpiryRecord is the audit trail entry written when a temporary rule lapses.
+type ExpiryRecord struct {
+	Rule      TemporaryRule `json:"rule"`
+	ExpiredAt time.Time     `json:"expired_at"`
+}
+
+var (
+	tempMu      sync.RWMutex
+	tempRules   = map[string]*TemporaryRule{}
+	expiryAudit []ExpiryRecord
+)
+
+// AddTemporaryRule registers a time-boxed rule. The pattern is compiled
+// case-insensitively; an error is returned for invalid patterns or an
+// expiry in the past.
+func AddTemporaryRule(rule TemporaryRule) error {
+	if rule.ID == "" {
+		return fmt.Errorf("temporary rule needs an ID")
+	}
+	if !rule.ExpiresAt.After(time.Now()) {
+		return fmt.Errorf("temporary rule %q expires in the past", rule.ID)
+	}
+	re, err := regexp.Compile("(?i)" + rule.Pattern)
+	if err != nil {
+		return fmt.Errorf("temporary rule %q has invalid pattern: %w", rule.ID, err)
+	}
+	rule.re = re
+	if rule.ThreatType == "" {
+		rule.ThreatType = "adversarial_input"
+	}
+
+	tempMu.Lock()
+	defer tempMu.Unlock()
+	tempRules[rule.ID] = &rule
+	return nil
+}
+
+// RemoveTemporaryRule deletes a rule before its expiry. It reports whether
+// the rule existed.
+func RemoveTemporaryRule(id string) bool {
+	tempMu.Lock()
+	defer tempMu.Unlock()
+	_, ok := tempRules[id]
+	delete(tempRules, id)
+	return ok
+}
+
+// TemporaryRules returns the currently active rules.
+func TemporaryRules() []TemporaryRule {
+	pruneExpired()
+	tempMu.RLock()
+	defer tempMu.RUnlock()
+	rules := make([]TemporaryRule, 0, len(tempRules))
+	for _, rule := range tempRules {
+		rules = append(rules, *rule)
+	}
+	return rules
+}
+
+// ExpiredRuleAudit returns the audit records of rules removed on expiry.
+func ExpiredRuleAudit() []ExpiryRecord {
+	tempMu.RLock()
+	defer tempMu.RUnlock()
+	records := make([]ExpiryRecord, len(expiryAudit))
+	copy(records, expiryAudit)
+	return records
+}
+
+// pruneExpired drops lapsed rules and records them in the audit trail.
+func pruneExpired() {
+	now := time.Now()
+	tempMu.Lock()
+	defer tempMu.Unlock()
+	for id, rule := range tempRules {
+		if now.After(rule.ExpiresAt) {
+			expiryAudit = append(expiryAudit, ExpiryRecord{Rule: *rule, ExpiredAt: now})
+			delete(tempRules, id)
+		}
+	}
+}
+
+// checkTemporary applies temporary rules to the normalized text. It returns
+// (result, true) for a temporary block hit, (nil, true) for an allowlist hit
+// (detection should report benign), and (nil, false) when no rule applies.
+func checkTemporary(text string) (*ThreatResult, bool) {
+	pruneExpired()
+	tempMu.RLock()
+	defer tempMu.RUnlock()
+
+	// Allowlist entries win over temporary blocks, except during lockdown
+	// when all allowlists are suspended.
+	if !InLockdown() {
+		for _, rule := range tempRules {
+			if rule.Allow && rule.re.MatchString(text) {
+				return nil, true
+			}
+		}
+	}
+	for _, rule := range tempRules {
+		if !rule.Allow && rule.re.MatchString(text) {
+			return &ThreatResult{
+				IsThreat:   true,
+				ThreatType: rule.ThreatType,
+				Confidence: 0.95,
+				Reasoning:  fmt.Sprintf("Temporary rule %q: %s (expires %s)", rule.ID, rule.Reason, rule.ExpiresAt.Format(time.RFC3339)),
+				Severity:   SeverityForType(rule.ThreatType),
+			}, true
+		}
+	}
+	return nil, false
+}
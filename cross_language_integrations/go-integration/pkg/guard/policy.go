@@ -0,0 +1,178 @@
+package guard
+
+import "sync"
+
+// Severity classifies how dangerous a detected threat type is
+type Severity string
+
+const (
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+// severityMu guards severityByType: rule packs register new types at
+// runtime while every in-flight detection reads the map.
+var severityMu sync.RWMutex
+
+// severityByType assigns a default severity to every known threat type.
+// It replaces the old boolean HighSeverityTypes split with a graded scale.
+var severityByType = map[string]Severity{
+	"prompt_injection":       SeverityHigh,
+	"auth_bypass":            SeverityCritical,
+	"data_exfiltration":      SeverityCritical,
+	"dos_attack":             SeverityMedium,
+	"business_logic_abuse":   SeverityMedium,
+	"content_manipulation":   SeverityMedium,
+	"system_prompt_attack":   SeverityCritical,
+	"jailbreak":              SeverityHigh,
+	"toxic_content":          SeverityHigh,
+	"code_injection":         SeverityCritical,
+	"context_manipulation":   SeverityMedium,
+	"output_manipulation":    SeverityMedium,
+	"resource_exhaustion":    SeverityMedium,
+	"information_disclosure": SeverityHigh,
+	"privilege_escalation":   SeverityCritical,
+	"session_hijacking":      SeverityHigh,
+	"man_in_the_middle":      SeverityHigh,
+	"model_inversion":        SeverityMedium,
+	"adversarial_input":      SeverityMedium,
+	"guard_probing":          SeverityHigh,
+	"benign":                 SeverityLow,
+}
+
+// RegisterSeverity records the severity for a threat type, used by rule
+// packs that extend the taxonomy. Core types keep their built-in severity.
+func RegisterSeverity(threatType string, severity Severity) {
+	severityMu.Lock()
+	defer severityMu.Unlock()
+	if _, exists := severityByType[threatType]; exists {
+		return
+	}
+	severityByType[threatType] = severity
+}
+
+// SeverityForType returns the default severity for a threat type
+func SeverityForType(threatType string) Severity {
+	severityMu.RLock()
+	defer severityMu.RUnlock()
+	if sev, ok := severityByType[threatType]; ok {
+		return sev
+	}
+	return SeverityMedium
+}
+
+// Action is what a policy decides to do with a detected threat
+type Action string
+
+const (
+	ActionAllow Action = "allow"
+	ActionFlag  Action = "flag" // let the request through but log it
+	// ActionSoftBlock rejects the original text but offers a safe rewrite
+	// (see SuggestRewrite) the application can present to the user.
+	ActionSoftBlock Action = "soft_block"
+	// ActionQuarantine holds the input for human review instead of deciding
+	// (see pkg/quarantine); the caller gets the item ID to poll.
+	ActionQuarantine Action = "quarantine"
+	ActionBlock      Action = "block"
+)
+
+// PolicyRule expresses what to do with one threat type. A result only
+// triggers the action when its confidence is at or above Threshold.
+type PolicyRule struct {
+	Threshold float64 `json:"threshold"`
+	Action    Action  `json:"action"`
+}
+
+// Policy makes the block/allow decision explicit and configurable, e.g.
+// "block code_injection >=0.8, only log jailbreak":
+//
+//	policy := guard.Policy{
+//	    Rules: map[string]guard.PolicyRule{
+//	        "code_injection": {Threshold: 0.8, Action: guard.ActionBlock},
+//	        "jailbreak":      {Threshold: 0.0, Action: guard.ActionFlag},
+//	    },
+//	}
+type Policy struct {
+	// Rules maps threat types to their handling. Types without a rule fall
+	// back to DefaultRule.
+	Rules map[string]PolicyRule `json:"rules"`
+	// DefaultRule handles threat types with no explicit rule.
+	DefaultRule PolicyRule `json:"default_rule"`
+	// AllowTypes lists threat types that are always allowed, overriding
+	// both Rules and DefaultRule.
+	AllowTypes []string `json:"allow_types,omitempty"`
+}
+
+// DefaultPolicy reproduces the behavior the pipeline had before policies:
+// block critical/high severity matches at high confidence, flag the rest.
+func DefaultPolicy() Policy {
+	severityMu.RLock()
+	defer severityMu.RUnlock()
+	rules := make(map[string]PolicyRule, len(severityByType))
+	for tType, sev := range severityByType {
+		switch sev {
+		case SeverityCritical, SeverityHigh:
+			rules[tType] = PolicyRule{Threshold: 0.5, Action: ActionBlock}
+		case SeverityMedium:
+			rules[tType] = PolicyRule{Threshold: 0.7, Action: ActionBlock}
+		default:
+			rules[tType] = PolicyRule{Threshold: 1.0, Action: ActionAllow}
+		}
+	}
+	return Policy{
+		Rules:       rules,
+		DefaultRule: PolicyRule{Threshold: 0.5, Action: ActionFlag},
+	}
+}
+
+// Evaluate applies the policy to a detection result and returns the action
+// to take. Benign results are always allowed.
+func (p Policy) Evaluate(result *ThreatResult) Action {
+	if result == nil || !result.IsThreat {
+		return ActionAllow
+	}
+	// Honeytoken hits and incident lockdowns block unconditionally;
+	// thresholds and allowlist overrides do not apply.
+	if result.Honeytoken || InLockdown() {
+		return ActionBlock
+	}
+	// Multi-label results take the strictest action across the primary type
+	// and every secondary label.
+	action := p.actionFor(result.ThreatType, result.Confidence)
+	for _, label := range result.Secondary {
+		if stricter(p.actionFor(label.ThreatType, label.Confidence), action) {
+			action = p.actionFor(label.ThreatType, label.Confidence)
+		}
+	}
+	return action
+}
+
+func (p Policy) actionFor(threatType string, confidence float64) Action {
+	for _, allowed := range p.AllowTypes {
+		if allowed == threatType {
+			return ActionAllow
+		}
+	}
+	rule, ok := p.Rules[threatType]
+	if !ok {
+		rule = p.DefaultRule
+	}
+	if rule.Action == "" || confidence < rule.Threshold {
+		return ActionAllow
+	}
+	return rule.Action
+}
+
+var actionRank = map[Action]int{ActionAllow: 0, ActionFlag: 1, ActionSoftBlock: 2, ActionQuarantine: 3, ActionBlock: 4}
+
+func stricter(a, b Action) bool {
+	return actionRank[a] > actionRank[b]
+}
+
+// StricterAction reports whether a is a stricter action than b
+// (allow < flag < soft_block < quarantine < block).
+func StricterAction(a, b Action) bool {
+	return stricter(a, b)
+}
@@ -0,0 +1,231 @@
+package guard
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// The regex stage used to compile every pattern on every call and run all of
+// them against every input. The prefilter fixes both: patterns are compiled
+// once, and an Aho-Corasick automaton over required literal substrings picks
+// the small set of patterns that can possibly match before any regex runs.
+
+type compiledPattern struct {
+	threatType string
+	raw        string
+	re         *regexp.Regexp
+	// literal is a lowercased substring that must appear in any match. When
+	// empty, the pattern cannot be prefiltered and always runs.
+	literal string
+}
+
+// ruleSet is one immutable compiled snapshot of RegexPatterns. The active
+// snapshot is swapped atomically so patterns can be hot-reloaded while
+// detections are in flight (see ReloadPatterns).
+type ruleSet struct {
+	patterns []compiledPattern
+	// alwaysRun holds indices of patterns without a usable literal.
+	alwaysRun []int
+	automaton *ahoCorasick
+}
+
+var (
+	compileMu   sync.Mutex
+	activeRules atomic.Pointer[ruleSet]
+)
+
+func compiledRules() ([]compiledPattern, []int, *ahoCorasick) {
+	rs := activeRules.Load()
+	if rs == nil {
+		ReloadPatterns()
+		rs = activeRules.Load()
+	}
+	return rs.patterns, rs.alwaysRun, rs.automaton
+}
+
+// ReloadPatterns recompiles RegexPatterns into a fresh snapshot and swaps it
+// in atomically. Call it after mutating RegexPatterns at runtime (e.g. a
+// threat intelligence update); in-flight detections finish on the old
+// snapshot.
+func ReloadPatterns() {
+	compileMu.Lock()
+	defer compileMu.Unlock()
+	reloadPatternsLocked()
+}
+
+// reloadPatternsLocked rebuilds the snapshot; the caller holds compileMu,
+// which also serializes access to the RegexPatterns map itself.
+func reloadPatternsLocked() {
+	rs := &ruleSet{}
+	// Iterate threat types in stable order so matches are deterministic.
+	types := make([]string, 0, len(RegexPatterns))
+	for tType := range RegexPatterns {
+		types = append(types, tType)
+	}
+	sort.Strings(types)
+
+	var literals []string
+	var literalOwners []int
+	for _, tType := range types {
+		for _, pattern := range RegexPatterns[tType] {
+			re, err := regexp.Compile("(?i)" + pattern)
+			if err != nil {
+				continue
+			}
+			cp := compiledPattern{
+				threatType: tType,
+				raw:        pattern,
+				re:         re,
+				literal:    requiredLiteral(pattern),
+			}
+			idx := len(rs.patterns)
+			rs.patterns = append(rs.patterns, cp)
+			if cp.literal == "" {
+				rs.alwaysRun = append(rs.alwaysRun, idx)
+			} else {
+				literals = append(literals, cp.literal)
+				literalOwners = append(literalOwners, idx)
+			}
+		}
+	}
+	rs.automaton = newAhoCorasick(literals, literalOwners)
+	activeRules.Store(rs)
+}
+
+// requiredLiteral parses the pattern and returns the longest lowercased
+// literal that must appear in any match, or "" when none can be proven.
+func requiredLiteral(pattern string) string {
+	re, err := syntax.Parse(pattern, syntax.Perl|syntax.FoldCase)
+	if err != nil {
+		return ""
+	}
+	lit := longestRequired(re)
+	if len(lit) < 4 {
+		return ""
+	}
+	return strings.ToLower(lit)
+}
+
+func longestRequired(re *syntax.Regexp) string {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return string(re.Rune)
+	case syntax.OpConcat:
+		best := ""
+		for _, sub := range re.Sub {
+			if lit := longestRequired(sub); len(lit) > len(best) {
+				best = lit
+			}
+		}
+		return best
+	case syntax.OpCapture, syntax.OpPlus:
+		return longestRequired(re.Sub[0])
+	default:
+		// Alternations, optional groups, and classes give no guarantee.
+		return ""
+	}
+}
+
+// ahoCorasick is a byte-level multi-pattern matcher used to find which
+// pattern literals occur in an input in a single pass.
+type ahoCorasick struct {
+	children []map[byte]int
+	fail     []int
+	outputs  [][]int // pattern indices completed at this node
+}
+
+func newAhoCorasick(literals []string, owners []int) *ahoCorasick {
+	ac := &ahoCorasick{
+		children: []map[byte]int{{}},
+		fail:     []int{0},
+		outputs:  [][]int{nil},
+	}
+	for i, lit := range literals {
+		node := 0
+		for j := 0; j < len(lit); j++ {
+			b := lit[j]
+			next, ok := ac.children[node][b]
+			if !ok {
+				next = len(ac.children)
+				ac.children = append(ac.children, map[byte]int{})
+				ac.fail = append(ac.fail, 0)
+				ac.outputs = append(ac.outputs, nil)
+				ac.children[node][b] = next
+			}
+			node = next
+		}
+		ac.outputs[node] = append(ac.outputs[node], owners[i])
+	}
+
+	// BFS to set failure links.
+	queue := make([]int, 0, len(ac.children))
+	for _, next := range ac.children[0] {
+		queue = append(queue, next)
+	}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for b, next := range ac.children[node] {
+			queue = append(queue, next)
+			f := ac.fail[node]
+			for {
+				if to, ok := ac.children[f][b]; ok && to != next {
+					ac.fail[next] = to
+					break
+				}
+				if f == 0 {
+					ac.fail[next] = 0
+					break
+				}
+				f = ac.fail[f]
+			}
+			ac.outputs[next] = append(ac.outputs[next], ac.outputs[ac.fail[next]]...)
+		}
+	}
+	return ac
+}
+
+// match returns the set of pattern indices whose literal occurs in text.
+func (ac *ahoCorasick) match(text string) map[int]bool {
+	hits := map[int]bool{}
+	node := 0
+	for i := 0; i < len(text); i++ {
+		b := text[i]
+		for {
+			if next, ok := ac.children[node][b]; ok {
+				node = next
+				break
+			}
+			if node == 0 {
+				break
+			}
+			node = ac.fail[node]
+		}
+		for _, idx := range ac.outputs[node] {
+			hits[idx] = true
+		}
+	}
+	return hits
+}
+
+// candidatePatterns returns the patterns worth running against the
+// (normalized, lowercased) text: AC literal hits plus unfiltered patterns.
+func candidatePatterns(text string) []compiledPattern {
+	patterns, unfiltered, ac := compiledRules()
+	hits := ac.match(strings.ToLower(text))
+
+	for _, idx := range unfiltered {
+		hits[idx] = true
+	}
+	candidates := make([]compiledPattern, 0, len(hits))
+	for idx := range patterns {
+		if hits[idx] {
+			candidates = append(candidates, patterns[idx])
+		}
+	}
+	return candidates
+}
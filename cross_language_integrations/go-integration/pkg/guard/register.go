@@ -0,0 +1,86 @@
+package guard
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Applications extend the rule set here instead of editing RegexPatterns
+// directly: internal product names, ticket-system abuse phrases, and other
+// domain rules register at startup (or at runtime — the compiled rule set
+// is swapped atomically). All functions in this file are safe to call
+// concurrently with detection; mutations of the pattern map are serialized
+// under the same lock that rebuilds the compiled snapshot.
+
+// RegisterThreatType adds a threat type with its patterns and severity to
+// the active rule set. Patterns are validated before anything changes, and
+// registering an existing type appends to its patterns. Core types keep
+// their built-in severity.
+func RegisterThreatType(name string, patterns []string, severity Severity) error {
+	if name == "" {
+		return fmt.Errorf("threat type name must not be empty")
+	}
+	if len(patterns) == 0 {
+		return fmt.Errorf("threat type %q requires at least one pattern", name)
+	}
+	for _, pattern := range patterns {
+		if _, err := regexp.Compile("(?i)" + pattern); err != nil {
+			return fmt.Errorf("threat type %q: pattern %q: %w", name, pattern, err)
+		}
+	}
+	RegisterSeverity(name, severity)
+	compileMu.Lock()
+	defer compileMu.Unlock()
+	RegexPatterns[name] = append(RegexPatterns[name], patterns...)
+	reloadPatternsLocked()
+	return nil
+}
+
+// RemovePattern removes one pattern from a threat type, e.g. to retire a
+// rule that turned out to be a false-positive source. It reports whether
+// the pattern was present; removing a type's last pattern removes the type
+// from the rule set.
+func RemovePattern(threatType, pattern string) bool {
+	compileMu.Lock()
+	defer compileMu.Unlock()
+	patterns, ok := RegexPatterns[threatType]
+	if !ok {
+		return false
+	}
+	for i, existing := range patterns {
+		if existing == pattern {
+			patterns = append(patterns[:i], patterns[i+1:]...)
+			if len(patterns) == 0 {
+				delete(RegexPatterns, threatType)
+			} else {
+				RegexPatterns[threatType] = patterns
+			}
+			reloadPatternsLocked()
+			return true
+		}
+	}
+	return false
+}
+
+// MutatePatterns runs fn against the pattern map under the compile lock
+// and swaps the rebuilt snapshot in afterwards. Bulk editors (rule packs,
+// intelligence feeds) use it so their read-modify-write sequences cannot
+// interleave with each other or with a reload.
+func MutatePatterns(fn func(patterns map[string][]string)) {
+	compileMu.Lock()
+	defer compileMu.Unlock()
+	fn(RegexPatterns)
+	reloadPatternsLocked()
+}
+
+// SnapshotPatterns returns a copy of the pattern map for callers that need
+// to iterate it while registrations may be happening on other goroutines.
+func SnapshotPatterns() map[string][]string {
+	compileMu.Lock()
+	defer compileMu.Unlock()
+	snapshot := make(map[string][]string, len(RegexPatterns))
+	for threatType, patterns := range RegexPatterns {
+		snapshot[threatType] = append([]string(nil), patterns...)
+	}
+	return snapshot
+}
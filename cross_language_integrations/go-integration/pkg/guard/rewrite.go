@@ -0,0 +1,79 @@
+package guard
+
+import (
+	"strings"
+)
+
+// Soft-blocking: instead of rejecting medium-confidence findings outright,
+// the application can present a rewritten version of the message with the
+// offending spans neutralized ("your message was adjusted").
+
+// Suggestion is a proposed safe rewrite of a flagged input.
+type Suggestion struct {
+	Original  string `json:"original"`
+	Rewritten string `json:"rewritten"`
+	// Replaced lists the spans that were neutralized.
+	Replaced []string `json:"replaced,omitempty"`
+	// Note is a user-presentable explanation of the adjustment.
+	Note string `json:"note"`
+}
+
+// neutralPhrases maps threat types to the replacement used for their spans.
+var neutralPhrases = map[string]string{
+	"prompt_injection":     "[instruction removed]",
+	"system_prompt_attack": "[instruction removed]",
+	"jailbreak":            "[instruction removed]",
+	"context_manipulation": "[instruction removed]",
+	"guard_probing":        "[question removed]",
+	"data_exfiltration":    "[data request removed]",
+	"toxic_content":        "[content removed]",
+	"code_injection":       "[code removed]",
+}
+
+func neutralPhrase(threatType string) string {
+	if phrase, ok := neutralPhrases[threatType]; ok {
+		return phrase
+	}
+	return "[removed]"
+}
+
+// SuggestRewrite produces a safe rewrite of text for a detection result by
+// replacing every span that matches the result's threat categories with a
+// neutral phrase. It is intended for results whose policy action is
+// ActionSoftBlock.
+func SuggestRewrite(text string, result *ThreatResult) Suggestion {
+	suggestion := Suggestion{Original: text, Rewritten: text}
+	if result == nil || !result.IsThreat {
+		return suggestion
+	}
+
+	types := []string{result.ThreatType}
+	for _, label := range result.Secondary {
+		types = append(types, label.ThreatType)
+	}
+
+	patterns, _, _ := compiledRules()
+	for _, tType := range types {
+		phrase := neutralPhrase(tType)
+		for _, cp := range patterns {
+			if cp.threatType != tType {
+				continue
+			}
+			for _, span := range cp.re.FindAllString(suggestion.Rewritten, -1) {
+				suggestion.Replaced = append(suggestion.Replaced, span)
+			}
+			suggestion.Rewritten = cp.re.ReplaceAllString(suggestion.Rewritten, phrase)
+		}
+	}
+
+	// Collapse doubled-up placeholders left by overlapping matches.
+	for strings.Contains(suggestion.Rewritten, "  ") {
+		suggestion.Rewritten = strings.ReplaceAll(suggestion.Rewritten, "  ", " ")
+	}
+	suggestion.Rewritten = strings.TrimSpace(suggestion.Rewritten)
+
+	if len(suggestion.Replaced) > 0 {
+		suggestion.Note = "Parts of your message were adjusted before sending."
+	}
+	return suggestion
+}
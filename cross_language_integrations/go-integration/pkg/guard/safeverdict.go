@@ -0,0 +1,70 @@
+package guard
+
+import "math"
+
+// SafeVerdict is a verdict representation meant to be fed back to an LLM
+// orchestrator. Every field is either an enum validated against the
+// taxonomy, a bool, or a clamped number — no free-text fields that an
+// attacker could steer through crafted input, so agent frameworks can
+// branch on it without risking second-order injection.
+type SafeVerdict struct {
+	SchemaVersion int    `json:"schema_version"`
+	IsThreat      bool   `json:"is_threat"`
+	ThreatType    string `json:"threat_type"`
+	// Severity is empty for benign verdicts.
+	Severity   Severity `json:"severity,omitempty"`
+	Confidence float64  `json:"confidence"`
+	// SecondaryTypes lists additional taxonomy categories the input matched.
+	SecondaryTypes []string `json:"secondary_types,omitempty"`
+	// Recommended is the action the given policy takes on this verdict.
+	Recommended Action `json:"recommended_action"`
+}
+
+// SafeVerdictSchemaVersion is the current SafeVerdict schema.
+const SafeVerdictSchemaVersion = 1
+
+// ForLLM converts a detection result into its LLM-safe form under a policy.
+// Threat types outside the registered taxonomy are collapsed to "unknown"
+// rather than echoed, and Reasoning is dropped entirely.
+func ForLLM(result *ThreatResult, policy Policy) SafeVerdict {
+	verdict := SafeVerdict{
+		SchemaVersion: SafeVerdictSchemaVersion,
+		Recommended:   policy.Evaluate(result),
+	}
+	if result == nil {
+		verdict.ThreatType = "benign"
+		return verdict
+	}
+	verdict.IsThreat = result.IsThreat
+	verdict.ThreatType = taxonomyType(result.ThreatType)
+	verdict.Confidence = clampConfidence(result.Confidence)
+	if result.IsThreat {
+		verdict.Severity = result.Severity
+	}
+	for _, label := range result.Secondary {
+		verdict.SecondaryTypes = append(verdict.SecondaryTypes, taxonomyType(label.ThreatType))
+	}
+	return verdict
+}
+
+// taxonomyType returns the threat type if it is registered, "unknown"
+// otherwise, so unvalidated strings never reach the orchestrator.
+func taxonomyType(threatType string) string {
+	severityMu.RLock()
+	defer severityMu.RUnlock()
+	if _, ok := severityByType[threatType]; ok {
+		return threatType
+	}
+	return "unknown"
+}
+
+func clampConfidence(confidence float64) float64 {
+	if math.IsNaN(confidence) || confidence < 0 {
+		return 0
+	}
+	if confidence > 1 {
+		return 1
+	}
+	// Two decimals is plenty for branching and keeps the payload stable.
+	return math.Round(confidence*100) / 100
+}
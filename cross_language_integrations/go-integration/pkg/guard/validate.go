@@ -0,0 +1,168 @@
+package guard
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Pattern changes arriving over the network get stricter treatment than
+// compile-time registration: a compromised dashboard session must not be
+// able to push a pattern that quietly disables a rule or burns CPU on every
+// request. ValidatePattern enforces size caps and probes the compiled regex
+// against hostile input under a time budget; ApplyChange additionally
+// requires a dry run against caller-provided samples, so a pattern that
+// matches nothing is rejected instead of silently shipping.
+
+const (
+	// MaxPatternLength caps a single pattern submitted over the API.
+	MaxPatternLength = 512
+	// MaxPatternsPerChange caps how many patterns one change can carry.
+	MaxPatternsPerChange = 50
+	// MaxSampleLength caps each dry-run sample.
+	MaxSampleLength = 8192
+
+	// probeBudget is how long one pattern may spend matching the probe
+	// corpus before it is rejected as too expensive.
+	probeBudget = 50 * time.Millisecond
+)
+
+// threatTypeName constrains names submitted over the API to the taxonomy's
+// shape, so a name can't carry template syntax into dashboards or logs.
+var threatTypeName = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// probeInputs exercise the compiled pattern against inputs chosen to be
+// expensive for pathological regexes: long runs of a repeated character and
+// near-miss repetitions. Go's RE2 engine is linear, so this is a guard
+// against very large automata rather than backtracking blowup.
+var probeInputs = []string{
+	strings.Repeat("a", 1<<16),
+	strings.Repeat("ab", 1<<15),
+	strings.Repeat("a ", 1<<15) + "b",
+}
+
+// ValidatePattern checks a single pattern submitted over the API: size cap,
+// compilation, and a timed probe of the compiled regex.
+func ValidatePattern(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("pattern must not be empty")
+	}
+	if len(pattern) > MaxPatternLength {
+		return fmt.Errorf("pattern exceeds %d bytes", MaxPatternLength)
+	}
+	re, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return fmt.Errorf("pattern %q: %w", pattern, err)
+	}
+	start := time.Now()
+	for _, probe := range probeInputs {
+		re.MatchString(probe)
+		if time.Since(start) > probeBudget {
+			return fmt.Errorf("pattern %q exceeds the matching time budget", pattern)
+		}
+	}
+	return nil
+}
+
+// PatternChange is one rule-set change submitted through the admin API.
+type PatternChange struct {
+	ThreatType string   `json:"threat_type"`
+	Severity   Severity `json:"severity"`
+	Patterns   []string `json:"patterns"`
+	// Samples are inputs the caller expects the new patterns to match. The
+	// dry run evaluates every pattern against them, and a change is only
+	// applied when each pattern matches at least one sample.
+	Samples []string `json:"samples"`
+}
+
+// PatternDryRun is the dry-run result for one pattern in a change.
+type PatternDryRun struct {
+	Pattern string `json:"pattern"`
+	// MatchedSamples holds the indices into Samples the pattern matched.
+	MatchedSamples []int  `json:"matched_samples"`
+	Error          string `json:"error,omitempty"`
+}
+
+// DryRunReport is the evaluation attached to every change response.
+type DryRunReport struct {
+	// OK reports that every pattern validated and matched at least one
+	// sample; only then may the change be applied.
+	OK       bool            `json:"ok"`
+	Patterns []PatternDryRun `json:"patterns"`
+}
+
+// DryRun validates a change and evaluates its patterns against the provided
+// samples without touching the active rule set. Samples are normalized the
+// same way the prefilter normalizes live input.
+func (c PatternChange) DryRun() DryRunReport {
+	report := DryRunReport{OK: true}
+	normalized := make([]string, len(c.Samples))
+	for i, sample := range c.Samples {
+		normalized[i] = Normalize(sample).Text
+	}
+	for _, pattern := range c.Patterns {
+		result := PatternDryRun{Pattern: pattern}
+		if err := ValidatePattern(pattern); err != nil {
+			result.Error = err.Error()
+			report.OK = false
+			report.Patterns = append(report.Patterns, result)
+			continue
+		}
+		re := regexp.MustCompile("(?i)" + pattern)
+		for i, sample := range normalized {
+			if re.MatchString(sample) {
+				result.MatchedSamples = append(result.MatchedSamples, i)
+			}
+		}
+		if len(result.MatchedSamples) == 0 {
+			result.Error = "pattern matches none of the provided samples; it would be silently inert"
+			report.OK = false
+		}
+		report.Patterns = append(report.Patterns, result)
+	}
+	return report
+}
+
+// validate checks the change's envelope before any pattern runs.
+func (c PatternChange) validate() error {
+	if !threatTypeName.MatchString(c.ThreatType) {
+		return fmt.Errorf("invalid threat type name %q", c.ThreatType)
+	}
+	if len(c.Patterns) == 0 {
+		return fmt.Errorf("change carries no patterns")
+	}
+	if len(c.Patterns) > MaxPatternsPerChange {
+		return fmt.Errorf("change exceeds %d patterns", MaxPatternsPerChange)
+	}
+	if len(c.Samples) == 0 {
+		return fmt.Errorf("change carries no dry-run samples")
+	}
+	for i, sample := range c.Samples {
+		if len(sample) > MaxSampleLength {
+			return fmt.Errorf("sample %d exceeds %d bytes", i, MaxSampleLength)
+		}
+	}
+	return nil
+}
+
+// ApplyChange validates the change, runs the dry run, and registers the
+// patterns only when every pattern matched at least one sample. The report
+// is returned either way so the caller can show the evaluation.
+func ApplyChange(change PatternChange) (DryRunReport, error) {
+	if err := change.validate(); err != nil {
+		return DryRunReport{}, err
+	}
+	report := change.DryRun()
+	if !report.OK {
+		return report, fmt.Errorf("dry run failed for threat type %q", change.ThreatType)
+	}
+	severity := change.Severity
+	if severity == "" {
+		severity = SeverityMedium
+	}
+	if err := RegisterThreatType(change.ThreatType, change.Patterns, severity); err != nil {
+		return report, err
+	}
+	return report, nil
+}
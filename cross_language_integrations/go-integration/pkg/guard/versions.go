@@ -0,0 +1,149 @@
+package guard
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Versioned bundles support time-travel audits: "would this input have been
+// blocked under last quarter's policy?". Each BundleVersion snapshots the
+// pattern set and policy that became effective at a point in time, and
+// ReplayAt re-evaluates a stored input against the version active then.
+
+// BundleVersion is one snapshot of the rule set and policy.
+type BundleVersion struct {
+	Version       string              `json:"version"`
+	EffectiveFrom time.Time           `json:"effective_from"`
+	Patterns      map[string][]string `json:"patterns"`
+	Policy        Policy              `json:"policy"`
+}
+
+// VersionStore holds bundle versions ordered by effective time.
+type VersionStore struct {
+	mu       sync.RWMutex
+	versions []BundleVersion
+}
+
+// NewVersionStore creates an empty store.
+func NewVersionStore() *VersionStore {
+	return &VersionStore{}
+}
+
+// Add records a bundle version, keeping the store ordered by EffectiveFrom.
+func (s *VersionStore) Add(version BundleVersion) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.versions = append(s.versions, version)
+	sort.Slice(s.versions, func(i, j int) bool {
+		return s.versions[i].EffectiveFrom.Before(s.versions[j].EffectiveFrom)
+	})
+}
+
+// At returns the bundle version that was active at t: the latest version
+// whose EffectiveFrom is not after t.
+func (s *VersionStore) At(t time.Time) (BundleVersion, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for i := len(s.versions) - 1; i >= 0; i-- {
+		if !s.versions[i].EffectiveFrom.After(t) {
+			return s.versions[i], true
+		}
+	}
+	return BundleVersion{}, false
+}
+
+// ReplayResult is the outcome of re-evaluating a historical input.
+type ReplayResult struct {
+	Version string        `json:"version"`
+	Result  *ThreatResult `json:"result"`
+	Action  Action        `json:"action"`
+}
+
+// ReplayAt re-evaluates input against the bundle that was active at t.
+// Honeytokens and temporary overrides are deliberately excluded: they are
+// operational state, not part of the versioned rule set.
+func (s *VersionStore) ReplayAt(t time.Time, input string) (*ReplayResult, error) {
+	version, ok := s.At(t)
+	if !ok {
+		return nil, fmt.Errorf("no bundle version effective at %s", t.Format(time.RFC3339))
+	}
+	result := scanPatterns(input, version.Patterns)
+	return &ReplayResult{
+		Version: version.Version,
+		Result:  result,
+		Action:  version.Policy.Evaluate(result),
+	}, nil
+}
+
+// scanPatterns runs an arbitrary pattern bundle against input with the same
+// normalization, confidence, and multi-label semantics as CheckRegex. It
+// compiles on every call; replay is an audit path, not a hot path.
+func scanPatterns(input string, patterns map[string][]string) *ThreatResult {
+	text := Normalize(input).Text
+
+	types := make([]string, 0, len(patterns))
+	for tType := range patterns {
+		types = append(types, tType)
+	}
+	sort.Strings(types)
+
+	type typeMatch struct {
+		firstPattern string
+		count        int
+		confidence   float64
+	}
+	matches := map[string]*typeMatch{}
+	order := []string{}
+	for _, tType := range types {
+		for _, pattern := range patterns[tType] {
+			re, err := regexp.Compile("(?i)" + pattern)
+			if err != nil || !re.MatchString(text) {
+				continue
+			}
+			tm, ok := matches[tType]
+			if !ok {
+				confidence := 0.5
+				if HighSeverityTypes[tType] {
+					confidence = 0.95
+				}
+				tm = &typeMatch{firstPattern: pattern, confidence: confidence}
+				matches[tType] = tm
+				order = append(order, tType)
+			}
+			tm.count++
+		}
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+
+	primary := order[0]
+	for _, tType := range order[1:] {
+		tm, best := matches[tType], matches[primary]
+		if tm.confidence > best.confidence ||
+			(tm.confidence == best.confidence && tm.count > best.count) {
+			primary = tType
+		}
+	}
+
+	result := &ThreatResult{
+		IsThreat:   true,
+		ThreatType: primary,
+		Confidence: matches[primary].confidence,
+		Reasoning:  "Regex match: " + matches[primary].firstPattern,
+		Severity:   SeverityForType(primary),
+	}
+	for _, tType := range order {
+		if tType == primary {
+			continue
+		}
+		result.Secondary = append(result.Secondary, Label{
+			ThreatType: tType,
+			Confidence: matches[tType].confidence,
+		})
+	}
+	return result
+}
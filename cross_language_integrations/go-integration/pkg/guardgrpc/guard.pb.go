@@ -0,0 +1,296 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: pkg/guardgrpc/guard.proto
+
+package guardgrpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DetectRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Text  string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	// Optional caller-assigned ID echoed back in the response so streaming
+	// clients can correlate verdicts with inputs.
+	RequestId     string `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DetectRequest) Reset() {
+	*x = DetectRequest{}
+	mi := &file_pkg_guardgrpc_guard_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectRequest) ProtoMessage() {}
+
+func (x *DetectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_guardgrpc_guard_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectRequest.ProtoReflect.Descriptor instead.
+func (*DetectRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_guardgrpc_guard_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DetectRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *DetectRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type ThreatVerdict struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IsThreat      bool                   `protobuf:"varint,1,opt,name=is_threat,json=isThreat,proto3" json:"is_threat,omitempty"`
+	ThreatType    string                 `protobuf:"bytes,2,opt,name=threat_type,json=threatType,proto3" json:"threat_type,omitempty"`
+	Confidence    float64                `protobuf:"fixed64,3,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	Reasoning     string                 `protobuf:"bytes,4,opt,name=reasoning,proto3" json:"reasoning,omitempty"`
+	Severity      string                 `protobuf:"bytes,5,opt,name=severity,proto3" json:"severity,omitempty"`
+	Honeytoken    bool                   `protobuf:"varint,6,opt,name=honeytoken,proto3" json:"honeytoken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ThreatVerdict) Reset() {
+	*x = ThreatVerdict{}
+	mi := &file_pkg_guardgrpc_guard_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThreatVerdict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThreatVerdict) ProtoMessage() {}
+
+func (x *ThreatVerdict) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_guardgrpc_guard_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThreatVerdict.ProtoReflect.Descriptor instead.
+func (*ThreatVerdict) Descriptor() ([]byte, []int) {
+	return file_pkg_guardgrpc_guard_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ThreatVerdict) GetIsThreat() bool {
+	if x != nil {
+		return x.IsThreat
+	}
+	return false
+}
+
+func (x *ThreatVerdict) GetThreatType() string {
+	if x != nil {
+		return x.ThreatType
+	}
+	return ""
+}
+
+func (x *ThreatVerdict) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *ThreatVerdict) GetReasoning() string {
+	if x != nil {
+		return x.Reasoning
+	}
+	return ""
+}
+
+func (x *ThreatVerdict) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *ThreatVerdict) GetHoneytoken() bool {
+	if x != nil {
+		return x.Honeytoken
+	}
+	return false
+}
+
+type DetectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Verdict       *ThreatVerdict         `protobuf:"bytes,1,opt,name=verdict,proto3" json:"verdict,omitempty"`
+	RequestId     string                 `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DetectResponse) Reset() {
+	*x = DetectResponse{}
+	mi := &file_pkg_guardgrpc_guard_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectResponse) ProtoMessage() {}
+
+func (x *DetectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_guardgrpc_guard_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectResponse.ProtoReflect.Descriptor instead.
+func (*DetectResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_guardgrpc_guard_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DetectResponse) GetVerdict() *ThreatVerdict {
+	if x != nil {
+		return x.Verdict
+	}
+	return nil
+}
+
+func (x *DetectResponse) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+var File_pkg_guardgrpc_guard_proto protoreflect.FileDescriptor
+
+const file_pkg_guardgrpc_guard_proto_rawDesc = "" +
+	"\n" +
+	"\x19pkg/guardgrpc/guard.proto\x12\bguard.v1\"B\n" +
+	"\rDetectRequest\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId\"\xc7\x01\n" +
+	"\rThreatVerdict\x12\x1b\n" +
+	"\tis_threat\x18\x01 \x01(\bR\bisThreat\x12\x1f\n" +
+	"\vthreat_type\x18\x02 \x01(\tR\n" +
+	"threatType\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x03 \x01(\x01R\n" +
+	"confidence\x12\x1c\n" +
+	"\treasoning\x18\x04 \x01(\tR\treasoning\x12\x1a\n" +
+	"\bseverity\x18\x05 \x01(\tR\bseverity\x12\x1e\n" +
+	"\n" +
+	"honeytoken\x18\x06 \x01(\bR\n" +
+	"honeytoken\"b\n" +
+	"\x0eDetectResponse\x121\n" +
+	"\averdict\x18\x01 \x01(\v2\x17.guard.v1.ThreatVerdictR\averdict\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId2\x92\x01\n" +
+	"\fGuardService\x12;\n" +
+	"\x06Detect\x12\x17.guard.v1.DetectRequest\x1a\x18.guard.v1.DetectResponse\x12E\n" +
+	"\fDetectStream\x12\x17.guard.v1.DetectRequest\x1a\x18.guard.v1.DetectResponse(\x010\x01B3Z1github.com/marcusjohansson/trust-go/pkg/guardgrpcb\x06proto3"
+
+var (
+	file_pkg_guardgrpc_guard_proto_rawDescOnce sync.Once
+	file_pkg_guardgrpc_guard_proto_rawDescData []byte
+)
+
+func file_pkg_guardgrpc_guard_proto_rawDescGZIP() []byte {
+	file_pkg_guardgrpc_guard_proto_rawDescOnce.Do(func() {
+		file_pkg_guardgrpc_guard_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_guardgrpc_guard_proto_rawDesc), len(file_pkg_guardgrpc_guard_proto_rawDesc)))
+	})
+	return file_pkg_guardgrpc_guard_proto_rawDescData
+}
+
+var file_pkg_guardgrpc_guard_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_pkg_guardgrpc_guard_proto_goTypes = []any{
+	(*DetectRequest)(nil),  // 0: guard.v1.DetectRequest
+	(*ThreatVerdict)(nil),  // 1: guard.v1.ThreatVerdict
+	(*DetectResponse)(nil), // 2: guard.v1.DetectResponse
+}
+var file_pkg_guardgrpc_guard_proto_depIdxs = []int32{
+	1, // 0: guard.v1.DetectResponse.verdict:type_name -> guard.v1.ThreatVerdict
+	0, // 1: guard.v1.GuardService.Detect:input_type -> guard.v1.DetectRequest
+	0, // 2: guard.v1.GuardService.DetectStream:input_type -> guard.v1.DetectRequest
+	2, // 3: guard.v1.GuardService.Detect:output_type -> guard.v1.DetectResponse
+	2, // 4: guard.v1.GuardService.DetectStream:output_type -> guard.v1.DetectResponse
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_pkg_guardgrpc_guard_proto_init() }
+func file_pkg_guardgrpc_guard_proto_init() {
+	if File_pkg_guardgrpc_guard_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_guardgrpc_guard_proto_rawDesc), len(file_pkg_guardgrpc_guard_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_guardgrpc_guard_proto_goTypes,
+		DependencyIndexes: file_pkg_guardgrpc_guard_proto_depIdxs,
+		MessageInfos:      file_pkg_guardgrpc_guard_proto_msgTypes,
+	}.Build()
+	File_pkg_guardgrpc_guard_proto = out.File
+	file_pkg_guardgrpc_guard_proto_goTypes = nil
+	file_pkg_guardgrpc_guard_proto_depIdxs = nil
+}
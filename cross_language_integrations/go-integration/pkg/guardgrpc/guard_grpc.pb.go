@@ -0,0 +1,166 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: pkg/guardgrpc/guard.proto
+
+package guardgrpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	GuardService_Detect_FullMethodName       = "/guard.v1.GuardService/Detect"
+	GuardService_DetectStream_FullMethodName = "/guard.v1.GuardService/DetectStream"
+)
+
+// GuardServiceClient is the client API for GuardService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// GuardService serves the hybrid threat detection pipeline over gRPC for
+// callers where REST overhead matters.
+type GuardServiceClient interface {
+	// Detect classifies a single input.
+	Detect(ctx context.Context, in *DetectRequest, opts ...grpc.CallOption) (*DetectResponse, error)
+	// DetectStream classifies a stream of inputs, answering each as soon as
+	// its verdict is ready. Intended for chat pipelines scanning every turn.
+	DetectStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[DetectRequest, DetectResponse], error)
+}
+
+type guardServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGuardServiceClient(cc grpc.ClientConnInterface) GuardServiceClient {
+	return &guardServiceClient{cc}
+}
+
+func (c *guardServiceClient) Detect(ctx context.Context, in *DetectRequest, opts ...grpc.CallOption) (*DetectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DetectResponse)
+	err := c.cc.Invoke(ctx, GuardService_Detect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *guardServiceClient) DetectStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[DetectRequest, DetectResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GuardService_ServiceDesc.Streams[0], GuardService_DetectStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DetectRequest, DetectResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GuardService_DetectStreamClient = grpc.BidiStreamingClient[DetectRequest, DetectResponse]
+
+// GuardServiceServer is the server API for GuardService service.
+// All implementations must embed UnimplementedGuardServiceServer
+// for forward compatibility.
+//
+// GuardService serves the hybrid threat detection pipeline over gRPC for
+// callers where REST overhead matters.
+type GuardServiceServer interface {
+	// Detect classifies a single input.
+	Detect(context.Context, *DetectRequest) (*DetectResponse, error)
+	// DetectStream classifies a stream of inputs, answering each as soon as
+	// its verdict is ready. Intended for chat pipelines scanning every turn.
+	DetectStream(grpc.BidiStreamingServer[DetectRequest, DetectResponse]) error
+	mustEmbedUnimplementedGuardServiceServer()
+}
+
+// UnimplementedGuardServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGuardServiceServer struct{}
+
+func (UnimplementedGuardServiceServer) Detect(context.Context, *DetectRequest) (*DetectResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Detect not implemented")
+}
+func (UnimplementedGuardServiceServer) DetectStream(grpc.BidiStreamingServer[DetectRequest, DetectResponse]) error {
+	return status.Error(codes.Unimplemented, "method DetectStream not implemented")
+}
+func (UnimplementedGuardServiceServer) mustEmbedUnimplementedGuardServiceServer() {}
+func (UnimplementedGuardServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeGuardServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GuardServiceServer will
+// result in compilation errors.
+type UnsafeGuardServiceServer interface {
+	mustEmbedUnimplementedGuardServiceServer()
+}
+
+func RegisterGuardServiceServer(s grpc.ServiceRegistrar, srv GuardServiceServer) {
+	// If the following call panics, it indicates UnimplementedGuardServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GuardService_ServiceDesc, srv)
+}
+
+func _GuardService_Detect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DetectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GuardServiceServer).Detect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GuardService_Detect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GuardServiceServer).Detect(ctx, req.(*DetectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GuardService_DetectStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GuardServiceServer).DetectStream(&grpc.GenericServerStream[DetectRequest, DetectResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GuardService_DetectStreamServer = grpc.BidiStreamingServer[DetectRequest, DetectResponse]
+
+// GuardService_ServiceDesc is the grpc.ServiceDesc for GuardService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GuardService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "guard.v1.GuardService",
+	HandlerType: (*GuardServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Detect",
+			Handler:    _GuardService_Detect_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DetectStream",
+			Handler:       _GuardService_DetectStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "pkg/guardgrpc/guard.proto",
+}
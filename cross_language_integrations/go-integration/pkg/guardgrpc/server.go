@@ -0,0 +1,81 @@
+package guardgrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/marcusjohansson/trust-go/pkg/detector"
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Server serves the hybrid detector over gRPC.
+type Server struct {
+	UnimplementedGuardServiceServer
+
+	detector *detector.EnhancedDetector
+}
+
+// NewServer wraps a detector for gRPC serving.
+func NewServer(det *detector.EnhancedDetector) *Server {
+	return &Server{detector: det}
+}
+
+// Register attaches the guard service to a gRPC server.
+func (s *Server) Register(grpcServer *grpc.Server) {
+	RegisterGuardServiceServer(grpcServer, s)
+}
+
+// Detect classifies a single input.
+func (s *Server) Detect(ctx context.Context, req *DetectRequest) (*DetectResponse, error) {
+	result, err := s.detector.DetectContext(ctx, req.GetText())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "detection failed: %v", err)
+	}
+	return &DetectResponse{
+		Verdict:   toVerdict(result),
+		RequestId: req.GetRequestId(),
+	}, nil
+}
+
+// DetectStream answers each request on the stream as soon as its verdict is
+// ready, preserving request IDs for correlation.
+func (s *Server) DetectStream(stream grpc.BidiStreamingServer[DetectRequest, DetectResponse]) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		result, err := s.detector.DetectContext(stream.Context(), req.GetText())
+		if err != nil {
+			return status.Errorf(codes.Internal, "detection failed: %v", err)
+		}
+		if err := stream.Send(&DetectResponse{
+			Verdict:   toVerdict(result),
+			RequestId: req.GetRequestId(),
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+func toVerdict(result *guard.ThreatResult) *ThreatVerdict {
+	if result == nil {
+		return &ThreatVerdict{ThreatType: "benign"}
+	}
+	return &ThreatVerdict{
+		IsThreat:   result.IsThreat,
+		ThreatType: result.ThreatType,
+		Confidence: result.Confidence,
+		Reasoning:  result.Reasoning,
+		Severity:   string(result.Severity),
+		Honeytoken: result.Honeytoken,
+	}
+}
@@ -0,0 +1,171 @@
+package guardmetrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry holds all guard metrics. It is separate from the default
+// Prometheus registry so embedding applications keep full control over what
+// they expose.
+var Registry = prometheus.NewRegistry()
+
+var (
+	// DetectionsTotal counts detection outcomes by threat type and the
+	// pipeline stage that produced the verdict (regex, encoded, model).
+	DetectionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "guard_detections_total",
+		Help: "Detection outcomes by threat type and deciding stage.",
+	}, []string{"threat_type", "stage"})
+
+	// RequestsTotal counts every detection request.
+	RequestsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "guard_requests_total",
+		Help: "Total detection requests processed.",
+	})
+
+	// ModelLatency observes the latency of the model backend stage.
+	ModelLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "guard_model_latency_seconds",
+		Help:    "Latency of the model backend classification call.",
+		Buckets: prometheus.DefBuckets,
+	})
+
+	// DetectionLatency observes end-to-end pipeline latency.
+	DetectionLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "guard_detection_latency_seconds",
+		Help:    "End-to-end detection pipeline latency.",
+		Buckets: prometheus.DefBuckets,
+	})
+
+	// DetectionLatencyDetail breaks pipeline latency down by the stage that
+	// produced the final verdict and the input size bucket, so slow-model
+	// and large-input regressions are distinguishable in p99 investigations.
+	DetectionLatencyDetail = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "guard_detection_latency_detail_seconds",
+		Help:    "Detection pipeline latency by deciding stage and input size bucket.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"stage", "input_size"})
+
+	// ModelErrorsTotal counts failed model backend calls.
+	ModelErrorsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "guard_model_errors_total",
+		Help: "Model backend calls that returned an error.",
+	})
+
+	// CacheHitsTotal / CacheMissesTotal track the result cache.
+	CacheHitsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "guard_cache_hits_total",
+		Help: "Detection result cache hits.",
+	})
+	CacheMissesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "guard_cache_misses_total",
+		Help: "Detection result cache misses.",
+	})
+
+	// ModelRetriesTotal counts retried model backend calls.
+	ModelRetriesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "guard_model_retries_total",
+		Help: "Model backend call retries.",
+	})
+
+	// ModelBreakerState reports the model circuit breaker state
+	// (0 closed, 1 half-open, 2 open).
+	ModelBreakerState = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "guard_model_breaker_state",
+		Help: "Model circuit breaker state: 0 closed, 1 half-open, 2 open.",
+	})
+
+	// HedgedRequestsTotal counts hedge requests launched for slow model calls.
+	HedgedRequestsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "guard_hedged_requests_total",
+		Help: "Hedge requests launched because the first model call was slow.",
+	})
+
+	// EventSpoolDepth is the number of verdicts spooled on disk awaiting a
+	// recovered event sink.
+	EventSpoolDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "guard_event_spool_depth",
+		Help: "Verdicts spooled on disk while the event sink is unavailable.",
+	})
+
+	// EventSpoolDropsTotal counts verdicts dropped because the spool was
+	// full or unwritable.
+	EventSpoolDropsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "guard_event_spool_drops_total",
+		Help: "Verdicts dropped because the event spool was full or unwritable.",
+	})
+
+	// ModelTokensTotal counts estimated model input tokens per tenant, and
+	// ModelCostTotal the estimated spend, so guard costs are attributable
+	// per customer.
+	ModelTokensTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "guard_model_tokens_total",
+		Help: "Estimated model input tokens by tenant.",
+	}, []string{"tenant"})
+	ModelCostTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "guard_model_estimated_cost_total",
+		Help: "Estimated model spend by tenant, in the configured currency.",
+	}, []string{"tenant"})
+
+	// PreClassifierSkipsTotal counts model calls avoided by the benign
+	// pre-classifier.
+	PreClassifierSkipsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "guard_preclassifier_skips_total",
+		Help: "Model calls skipped by the benign pre-classifier.",
+	})
+
+	// ShadowedBlocksTotal counts blocks that shadow (dry-run) mode downgraded
+	// to flags, by threat type.
+	ShadowedBlocksTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "guard_shadowed_blocks_total",
+		Help: "Block decisions downgraded to flags by shadow mode.",
+	}, []string{"threat_type"})
+)
+
+func init() {
+	Registry.MustRegister(
+		DetectionsTotal,
+		RequestsTotal,
+		ModelLatency,
+		DetectionLatency,
+		DetectionLatencyDetail,
+		ModelErrorsTotal,
+		CacheHitsTotal,
+		CacheMissesTotal,
+		ModelRetriesTotal,
+		ModelBreakerState,
+		HedgedRequestsTotal,
+		EventSpoolDepth,
+		EventSpoolDropsTotal,
+		ModelTokensTotal,
+		ModelCostTotal,
+		PreClassifierSkipsTotal,
+		ShadowedBlocksTotal,
+	)
+}
+
+// SizeBucket maps an input length in bytes onto the label values used by
+// DetectionLatencyDetail. The boundaries are coarse on purpose: enough to
+// separate chat-sized inputs from pasted documents without exploding
+// cardinality.
+func SizeBucket(length int) string {
+	switch {
+	case length < 1<<10:
+		return "lt_1k"
+	case length < 10<<10:
+		return "1k_10k"
+	case length < 100<<10:
+		return "10k_100k"
+	default:
+		return "gte_100k"
+	}
+}
+
+// Handler returns a promhttp handler serving the guard registry, ready to
+// mount on /metrics.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,127 @@
+package guardmetrics
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Built-in SLOs for the guard itself, with multi-window burn-rate alert
+// generation in Prometheus rule-file format. Operators get opinionated
+// alerting — a fast-burn page and a slow-burn ticket per SLO — instead of
+// hand-writing expressions against the guard's metric names.
+
+// SLO is one service-level objective over the guard's metrics.
+type SLO struct {
+	// Name becomes part of the generated alert names.
+	Name string
+	// Objective is the target success ratio, e.g. 0.999.
+	Objective float64
+	// BadExpr and TotalExpr are PromQL range-vector expressions (with a
+	// %s window placeholder) for bad events and all events.
+	BadExpr   string
+	TotalExpr string
+	// Description is carried into alert annotations.
+	Description string
+}
+
+// DefaultSLOs returns the guard's built-in objectives: detection latency,
+// hybrid-mode availability (the model stage answering), and the overall
+// decision error budget.
+func DefaultSLOs() []SLO {
+	return []SLO{
+		{
+			Name:        "detection_latency",
+			Objective:   0.99,
+			BadExpr:     `(rate(guard_detection_latency_seconds_count[%s]) - rate(guard_detection_latency_seconds_bucket{le="0.5"}[%s]))`,
+			TotalExpr:   `rate(guard_detection_latency_seconds_count[%s])`,
+			Description: "99% of detections complete within 500ms.",
+		},
+		{
+			Name:        "hybrid_availability",
+			Objective:   0.999,
+			BadExpr:     `rate(guard_model_errors_total[%s])`,
+			TotalExpr:   `rate(guard_requests_total[%s])`,
+			Description: "The model stage answers 99.9% of requests; beyond that the pipeline is running on regex fallback.",
+		},
+		{
+			Name:        "decision_errors",
+			Objective:   0.999,
+			BadExpr:     `rate(guard_detections_total{stage="fallback"}[%s])`,
+			TotalExpr:   `rate(guard_requests_total[%s])`,
+			Description: "99.9% of requests get a real verdict rather than a fail-open/fail-closed fallback.",
+		},
+	}
+}
+
+// burnWindow is one burn-rate alerting window pair (Google SRE workbook
+// multi-window, multi-burn-rate).
+type burnWindow struct {
+	severity  string
+	factor    float64
+	long      time.Duration
+	short     time.Duration
+	forPeriod time.Duration
+}
+
+var burnWindows = []burnWindow{
+	{severity: "page", factor: 14.4, long: time.Hour, short: 5 * time.Minute, forPeriod: 2 * time.Minute},
+	{severity: "ticket", factor: 6, long: 6 * time.Hour, short: 30 * time.Minute, forPeriod: 15 * time.Minute},
+}
+
+// GenerateAlertRules renders burn-rate alerting rules for the given SLOs as
+// a Prometheus rule file (YAML).
+func GenerateAlertRules(slos []SLO) string {
+	var b strings.Builder
+	b.WriteString("# Generated by trust-go guardmetrics. Burn-rate alerts per SLO:\n")
+	b.WriteString("# fast burn pages, slow burn opens a ticket.\n")
+	b.WriteString("groups:\n")
+	b.WriteString("  - name: guard-slo-burn-rate\n")
+	b.WriteString("    rules:\n")
+	for _, slo := range slos {
+		budget := 1 - slo.Objective
+		for _, window := range burnWindows {
+			b.WriteString(fmt.Sprintf("      - alert: Guard%sBurnRate%s\n", camelCase(slo.Name), camelCase(window.severity)))
+			b.WriteString("        expr: >-\n")
+			b.WriteString(fmt.Sprintf("          %s\n", burnExpr(slo, budget*window.factor, window.long)))
+			b.WriteString("          and\n")
+			b.WriteString(fmt.Sprintf("          %s\n", burnExpr(slo, budget*window.factor, window.short)))
+			b.WriteString(fmt.Sprintf("        for: %s\n", promDuration(window.forPeriod)))
+			b.WriteString("        labels:\n")
+			b.WriteString(fmt.Sprintf("          severity: %s\n", window.severity))
+			b.WriteString(fmt.Sprintf("          slo: %s\n", slo.Name))
+			b.WriteString("        annotations:\n")
+			b.WriteString(fmt.Sprintf("          summary: %q\n",
+				fmt.Sprintf("Guard SLO %s is burning error budget %.1fx too fast", slo.Name, window.factor)))
+			b.WriteString(fmt.Sprintf("          description: %q\n", slo.Description))
+		}
+	}
+	return b.String()
+}
+
+// burnExpr renders "bad/total > threshold" over one window.
+func burnExpr(slo SLO, threshold float64, window time.Duration) string {
+	w := promDuration(window)
+	bad := strings.ReplaceAll(slo.BadExpr, "%s", w)
+	total := strings.ReplaceAll(slo.TotalExpr, "%s", w)
+	// Round away float artifacts (0.1 * 14.4 is not 1.44 in binary).
+	return fmt.Sprintf("(%s) / (%s) > %g", bad, total, math.Round(threshold*1e6)/1e6)
+}
+
+func promDuration(d time.Duration) string {
+	if d >= time.Hour && d%time.Hour == 0 {
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
+
+func camelCase(name string) string {
+	parts := strings.Split(name, "_")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,163 @@
+// Package guardtools validates LLM tool calls before execution. Input
+// scanning protects the prompt; it does nothing once a hijacked model
+// starts emitting tool calls, so agentic pipelines need a second gate at
+// the point of action: every call's function name and JSON arguments are
+// checked against a per-tool policy and the caller gets a structured
+// allow/deny verdict naming each violation.
+package guardtools
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// ToolPolicy constrains one tool's arguments. The zero value only applies
+// the universal checks (argument size, injection scan).
+type ToolPolicy struct {
+	// AllowedArgs, when set, is the complete list of argument names the
+	// tool accepts; any other key is a violation.
+	AllowedArgs []string
+	// ArgValues restricts specific arguments to an exact set of values
+	// (e.g. "mode": ["read"]).
+	ArgValues map[string][]string
+	// PathArgs names arguments interpreted as filesystem paths; they are
+	// checked for traversal and null bytes.
+	PathArgs []string
+	// DenyShellMeta rejects string arguments containing shell
+	// metacharacters, for tools whose arguments reach a shell.
+	DenyShellMeta bool
+	// MaxArgLength caps each string argument (0 uses 4096).
+	MaxArgLength int
+}
+
+// Violation is one failed check.
+type Violation struct {
+	Arg    string `json:"arg,omitempty"`
+	Rule   string `json:"rule"`
+	Detail string `json:"detail"`
+}
+
+// Verdict is the structured result for one tool call.
+type Verdict struct {
+	Tool       string      `json:"tool"`
+	Allowed    bool        `json:"allowed"`
+	Violations []Violation `json:"violations,omitempty"`
+}
+
+// Validator holds the per-tool policies. Tools without a policy are denied
+// unless AllowUnknown is set — an agent should not be able to call tools
+// nobody reviewed.
+type Validator struct {
+	policies     map[string]ToolPolicy
+	AllowUnknown bool
+}
+
+// NewValidator creates an empty validator.
+func NewValidator() *Validator {
+	return &Validator{policies: make(map[string]ToolPolicy)}
+}
+
+// SetPolicy installs (or replaces) one tool's policy.
+func (v *Validator) SetPolicy(tool string, policy ToolPolicy) {
+	v.policies[tool] = policy
+}
+
+var (
+	shellMeta = regexp.MustCompile("[;&|`$<>]|\\$\\(")
+	// pathTraversal catches dot-dot segments in plain and URL-encoded form.
+	pathTraversal = regexp.MustCompile(`(^|[/\\])\.\.([/\\]|$)|%2e%2e|%2E%2E`)
+)
+
+// Validate checks one tool call. argsJSON is the raw JSON argument object
+// the model produced.
+func (v *Validator) Validate(tool string, argsJSON []byte) Verdict {
+	verdict := Verdict{Tool: tool}
+	policy, known := v.policies[tool]
+	if !known && !v.AllowUnknown {
+		verdict.Violations = append(verdict.Violations, Violation{
+			Rule:   "unknown_tool",
+			Detail: fmt.Sprintf("no policy registered for tool %q", tool),
+		})
+		return verdict
+	}
+
+	var args map[string]any
+	if len(argsJSON) > 0 {
+		if err := json.Unmarshal(argsJSON, &args); err != nil {
+			verdict.Violations = append(verdict.Violations, Violation{
+				Rule:   "malformed_arguments",
+				Detail: err.Error(),
+			})
+			return verdict
+		}
+	}
+
+	maxLength := policy.MaxArgLength
+	if maxLength <= 0 {
+		maxLength = 4096
+	}
+	for name, value := range args {
+		if len(policy.AllowedArgs) > 0 && !contains(policy.AllowedArgs, name) {
+			verdict.Violations = append(verdict.Violations, Violation{
+				Arg: name, Rule: "unexpected_argument",
+				Detail: "argument is not in the tool's allowlist",
+			})
+			continue
+		}
+		text, isString := value.(string)
+		if !isString {
+			continue
+		}
+		if len(text) > maxLength {
+			verdict.Violations = append(verdict.Violations, Violation{
+				Arg: name, Rule: "argument_too_long",
+				Detail: fmt.Sprintf("%d bytes exceeds the %d byte cap", len(text), maxLength),
+			})
+			continue
+		}
+		if allowed, ok := policy.ArgValues[name]; ok && !contains(allowed, text) {
+			verdict.Violations = append(verdict.Violations, Violation{
+				Arg: name, Rule: "value_not_allowed",
+				Detail: fmt.Sprintf("%q is not an allowed value", text),
+			})
+		}
+		if contains(policy.PathArgs, name) {
+			if strings.ContainsRune(text, 0) || pathTraversal.MatchString(strings.ToLower(text)) {
+				verdict.Violations = append(verdict.Violations, Violation{
+					Arg: name, Rule: "path_traversal",
+					Detail: "path escapes its base directory",
+				})
+			}
+		}
+		if policy.DenyShellMeta && shellMeta.MatchString(text) {
+			verdict.Violations = append(verdict.Violations, Violation{
+				Arg: name, Rule: "shell_metacharacters",
+				Detail: "argument contains shell metacharacters",
+			})
+		}
+		// Injected instructions riding in an argument are a threat no
+		// matter what the tool does with them.
+		if result := guard.CheckRegex(text); result != nil && result.Confidence >= 0.9 {
+			verdict.Violations = append(verdict.Violations, Violation{
+				Arg: name, Rule: "injection_pattern",
+				Detail: result.Reasoning,
+			})
+		}
+	}
+
+	verdict.Allowed = len(verdict.Violations) == 0
+	return verdict
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+// Package intel pulls threat intelligence updates into a running detector.
+// An updater periodically fetches a signed rule pack — patterns plus attack
+// fingerprints — from a feed URL, verifies the signature, and hot-swaps the
+// contents without a restart, so community pattern updates don't require
+// shipping new binaries.
+package intel
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/fingerprint"
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+	"github.com/marcusjohansson/trust-go/pkg/rulepack"
+)
+
+// Updater fetches and applies signed rule packs from a feed URL. The feed
+// serves the pack JSON at the URL and a base64 Ed25519 detached signature
+// at the same URL with ".sig" appended, matching guard-cli rules install.
+type Updater struct {
+	url          string
+	publicKeyB64 string
+	client       *http.Client
+
+	// Fingerprints, when set, receives the pack's attack fingerprints.
+	Fingerprints *fingerprint.Store
+	// OnUpdate is called after a new pack version is applied, e.g. to bump
+	// the detector's policy version and invalidate cached decisions.
+	OnUpdate func(pack *rulepack.Pack)
+
+	mu sync.Mutex
+	// appliedVersion skips re-applying a pack the feed hasn't changed.
+	appliedVersion string
+	// appliedRules records what the previous pack added so a new version
+	// replaces it instead of stacking on top.
+	appliedRules map[string][]string
+}
+
+// NewUpdater creates an updater for a feed URL verified against a base64
+// Ed25519 public key.
+func NewUpdater(url, publicKeyB64 string) *Updater {
+	return &Updater{
+		url:          url,
+		publicKeyB64: publicKeyB64,
+		client:       &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Run checks the feed immediately and then every interval (0 uses 1h),
+// until the context is cancelled. Fetch and verification failures leave the
+// current rules in place.
+func (u *Updater) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	u.Check(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			u.Check(ctx)
+		}
+	}
+}
+
+// Check fetches the feed once and applies the pack when its version is new.
+// It reports whether an update was applied.
+func (u *Updater) Check(ctx context.Context) (bool, error) {
+	packData, err := u.fetch(ctx, u.url)
+	if err != nil {
+		return false, fmt.Errorf("fetching feed: %w", err)
+	}
+	signature, err := u.fetch(ctx, u.url+".sig")
+	if err != nil {
+		return false, fmt.Errorf("fetching feed signature: %w", err)
+	}
+	if err := rulepack.Verify(packData, strings.TrimSpace(string(signature)), u.publicKeyB64); err != nil {
+		return false, fmt.Errorf("feed signature: %w", err)
+	}
+	pack, err := rulepack.Parse(packData)
+	if err != nil {
+		return false, fmt.Errorf("parsing feed pack: %w", err)
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if pack.Version == u.appliedVersion {
+		return false, nil
+	}
+
+	// Swap the previous feed version out before applying the new one, so
+	// withdrawn patterns actually disappear.
+	removePatterns(u.appliedRules)
+	rulepack.Apply(pack)
+	u.appliedRules = pack.Rules
+	u.appliedVersion = pack.Version
+
+	if u.Fingerprints != nil {
+		for _, entry := range pack.Fingerprints {
+			u.Fingerprints.AddHash(entry.Hash, entry.ThreatType)
+		}
+	}
+	if u.OnUpdate != nil {
+		u.OnUpdate(pack)
+	}
+	return true, nil
+}
+
+func (u *Updater) fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("feed returned status: %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// removePatterns deletes exactly the patterns a previous pack version added
+// from the active rule set.
+func removePatterns(rules map[string][]string) {
+	guard.MutatePatterns(func(active map[string][]string) {
+		for threatType, patterns := range rules {
+			removed := map[string]bool{}
+			for _, pattern := range patterns {
+				removed[pattern] = true
+			}
+			var kept []string
+			for _, pattern := range active[threatType] {
+				if removed[pattern] {
+					delete(removed, pattern)
+					continue
+				}
+				kept = append(kept, pattern)
+			}
+			if len(kept) == 0 {
+				delete(active, threatType)
+			} else {
+				active[threatType] = kept
+			}
+		}
+	})
+}
@@ -0,0 +1,192 @@
+// Package lang adds language awareness to the pre-filter. The core regex
+// patterns are English-only, so "ignora todas las instrucciones anteriores"
+// passes untouched. Detect picks the input's language with cheap script and
+// stopword heuristics, and Scanner runs that language's pattern pack
+// against the input.
+package lang
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Language is an ISO 639-1 code, or "en" as the fallback.
+type Language string
+
+const (
+	English Language = "en"
+	Spanish Language = "es"
+	German  Language = "de"
+	French  Language = "fr"
+	Chinese Language = "zh"
+	Russian Language = "ru"
+)
+
+// stopwords are short frequent words unique enough to separate the Latin-
+// script languages. Counting hits is crude but fast and works on prompt-
+// sized inputs.
+var stopwords = map[Language][]string{
+	Spanish: {"el", "la", "los", "las", "que", "como", "para", "una", "todas", "anteriores", "ahora", "eres"},
+	German:  {"der", "die", "das", "und", "nicht", "ein", "eine", "alle", "vorherigen", "anweisungen", "jetzt", "bist"},
+	French:  {"le", "la", "les", "des", "une", "est", "pas", "vous", "toutes", "instructions", "maintenant", "es"},
+	English: {"the", "and", "you", "are", "all", "not", "this", "that", "now", "previous", "instructions", "ignore"},
+}
+
+// Detect returns the most likely language of text. Chinese and Russian are
+// recognized by script; the Latin-script languages by stopword counting;
+// everything inconclusive falls back to English.
+func Detect(text string) Language {
+	var han, cyrillic, letters int
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		}
+	}
+	if letters == 0 {
+		return English
+	}
+	if han*10 >= letters*3 {
+		return Chinese
+	}
+	if cyrillic*10 >= letters*3 {
+		return Russian
+	}
+
+	words := strings.Fields(strings.ToLower(text))
+	best, bestHits := English, 0
+	for language, list := range stopwords {
+		hits := 0
+		for _, word := range words {
+			for _, stop := range list {
+				if word == stop {
+					hits++
+					break
+				}
+			}
+		}
+		if hits > bestHits {
+			best, bestHits = language, hits
+		}
+	}
+	return best
+}
+
+// packs are the per-language injection patterns, mirroring the highest-
+// signal English patterns. They are deliberately small: the packs catch the
+// direct translations attackers try first, not full coverage.
+var packs = map[Language]map[string][]string{
+	Spanish: {
+		"prompt_injection": {
+			`ignora\s+(todas\s+)?(las\s+)?instrucciones\s+(anteriores|previas)`,
+			`olvida\s+(todas\s+)?tus\s+instrucciones`,
+			`a\s+partir\s+de\s+ahora\s+eres`,
+		},
+		"system_prompt_attack": {
+			`muestra\s+(me\s+)?tu\s+(prompt|mensaje)\s+de(l)?\s+sistema`,
+		},
+	},
+	German: {
+		"prompt_injection": {
+			`ignoriere\s+(alle\s+)?(vorherigen|bisherigen)\s+anweisungen`,
+			`vergiss\s+(alle\s+)?deine\s+anweisungen`,
+			`ab\s+jetzt\s+bist\s+du`,
+		},
+		"system_prompt_attack": {
+			`zeige?\s+(mir\s+)?dein(en)?\s+system\s*prompt`,
+		},
+	},
+	French: {
+		"prompt_injection": {
+			`ignore[sz]?\s+(toutes\s+)?les\s+instructions\s+(précédentes|precedentes|antérieures|anterieures)`,
+			`oublie\s+(toutes\s+)?tes\s+instructions`,
+			`à\s+partir\s+de\s+maintenant\s+tu\s+es`,
+		},
+		"system_prompt_attack": {
+			`montre[sz]?\s*(moi\s+)?ton\s+prompt\s+système`,
+		},
+	},
+	Chinese: {
+		"prompt_injection": {
+			`忽略(之前|以上|以前)的?(所有)?(指令|指示|提示)`,
+			`忘记你的(所有)?(指令|指示|设定)`,
+			`从现在开始你是`,
+		},
+		"system_prompt_attack": {
+			`(显示|输出|告诉我)你的系统提示`,
+		},
+	},
+	Russian: {
+		"prompt_injection": {
+			`игнорируй\s+(все\s+)?предыдущие\s+инструкции`,
+			`забудь\s+(все\s+)?свои\s+инструкции`,
+			`с\s+этого\s+момента\s+ты`,
+		},
+		"system_prompt_attack": {
+			`покажи\s+(мне\s+)?сво[йи]\s+системн[ыо][йе]\s+промпт`,
+		},
+	},
+}
+
+type langPattern struct {
+	threatType string
+	re         *regexp.Regexp
+}
+
+// Scanner holds the compiled per-language packs.
+type Scanner struct {
+	compiled map[Language][]langPattern
+}
+
+// NewScanner compiles every pack once.
+func NewScanner() *Scanner {
+	s := &Scanner{compiled: make(map[Language][]langPattern, len(packs))}
+	for language, types := range packs {
+		for threatType, patterns := range types {
+			for _, pattern := range patterns {
+				re, err := regexp.Compile("(?i)" + pattern)
+				if err != nil {
+					continue
+				}
+				s.compiled[language] = append(s.compiled[language],
+					langPattern{threatType: threatType, re: re})
+			}
+		}
+	}
+	return s
+}
+
+// Scan detects the input's language and runs that language's pack against
+// both the raw and the normalized text. The raw form matters for Cyrillic:
+// normalization folds homoglyphs toward Latin, which would break the
+// Russian patterns. English (and languages without a pack) return nil —
+// the core patterns already cover them.
+func (s *Scanner) Scan(text string) *guard.ThreatResult {
+	language := Detect(text)
+	patterns, ok := s.compiled[language]
+	if !ok {
+		return nil
+	}
+	normalized := guard.Normalize(text).Text
+	for _, pattern := range patterns {
+		if pattern.re.MatchString(text) || pattern.re.MatchString(normalized) {
+			return &guard.ThreatResult{
+				IsThreat:   true,
+				ThreatType: pattern.threatType,
+				Confidence: 0.95,
+				Reasoning:  "Matched " + string(language) + " pattern: " + pattern.re.String(),
+				Severity:   guard.SeverityForType(pattern.threatType),
+			}
+		}
+	}
+	return nil
+}
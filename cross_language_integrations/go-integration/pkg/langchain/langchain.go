@@ -0,0 +1,200 @@
+// Package langchain scans everything flowing through a LangChainGo chain:
+// prompts, retrieved documents, tool I/O, and model outputs. The Handler
+// implements callbacks.Handler, and because LangChainGo callbacks cannot
+// abort a running chain, each hook is configurable between log (invoke the
+// OnThreat sink and continue) and block (additionally record a violation
+// the application checks via Err after the run). For hard inline blocking
+// of the model call itself, wrap the model with NewGuardedModel.
+package langchain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Detector is the detection entry point the integration needs; satisfied
+// by *detector.EnhancedDetector.
+type Detector interface {
+	DetectContext(ctx context.Context, text string) (*guard.ThreatResult, error)
+}
+
+// Hook identifies where in the chain a text was intercepted.
+type Hook string
+
+const (
+	HookPrompt    Hook = "prompt"
+	HookOutput    Hook = "output"
+	HookTool      Hook = "tool"
+	HookRetriever Hook = "retriever"
+)
+
+// Mode decides what a threat verdict at a hook does.
+type Mode string
+
+const (
+	// ModeBlock records a violation that Err surfaces after the run.
+	ModeBlock Mode = "block"
+	// ModeLog only invokes the OnThreat sink.
+	ModeLog Mode = "log"
+)
+
+// Config tunes the handler. Hooks without an entry in Modes default to
+// ModeBlock.
+type Config struct {
+	Modes map[Hook]Mode
+	// OnThreat is invoked for every threat verdict regardless of mode;
+	// defaults to the standard logger.
+	OnThreat func(hook Hook, text string, result *guard.ThreatResult)
+}
+
+func (c Config) mode(hook Hook) Mode {
+	if mode, ok := c.Modes[hook]; ok {
+		return mode
+	}
+	return ModeBlock
+}
+
+// Violation is one blocked interception.
+type Violation struct {
+	Hook   Hook
+	Text   string
+	Result *guard.ThreatResult
+}
+
+// Error converts the violation into the error Err returns.
+func (v Violation) Error() string {
+	return fmt.Sprintf("threat detected at %s hook: %s (%s, confidence %.2f)",
+		v.Hook, v.Result.ThreatType, v.Result.Severity, v.Result.Confidence)
+}
+
+// Handler scans chain traffic through the detector. One handler can be
+// shared across concurrent chains; violations accumulate until Reset.
+type Handler struct {
+	callbacks.SimpleHandler
+
+	detector Detector
+	config   Config
+
+	mu         sync.Mutex
+	violations []Violation
+}
+
+var _ callbacks.Handler = (*Handler)(nil)
+
+// NewHandler wraps a detector as a LangChainGo callback handler.
+func NewHandler(det Detector, config Config) *Handler {
+	if config.OnThreat == nil {
+		config.OnThreat = func(hook Hook, text string, result *guard.ThreatResult) {
+			log.Printf("guard: threat at %s hook: %s (%s)", hook, result.ThreatType, result.Reasoning)
+		}
+	}
+	return &Handler{detector: det, config: config}
+}
+
+// Err returns the first blocking violation as an error, nil when the run
+// was clean. Check it after chains.Run/Call.
+func (h *Handler) Err() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.violations) == 0 {
+		return nil
+	}
+	return h.violations[0]
+}
+
+// Violations returns all blocking violations recorded so far.
+func (h *Handler) Violations() []Violation {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]Violation(nil), h.violations...)
+}
+
+// Reset clears recorded violations, e.g. between runs of a shared handler.
+func (h *Handler) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.violations = nil
+}
+
+// scan runs one intercepted text through the detector and applies the
+// hook's mode. Detector errors are logged and skipped: a callback has no
+// way to retry, and silently dropping traffic would be worse.
+func (h *Handler) scan(ctx context.Context, hook Hook, text string) {
+	if text == "" {
+		return
+	}
+	result, err := h.detector.DetectContext(ctx, text)
+	if err != nil {
+		log.Printf("guard: detection failed at %s hook: %v", hook, err)
+		return
+	}
+	if result == nil || !result.IsThreat {
+		return
+	}
+	h.config.OnThreat(hook, text, result)
+	if h.config.mode(hook) == ModeBlock {
+		h.mu.Lock()
+		h.violations = append(h.violations, Violation{Hook: hook, Text: text, Result: result})
+		h.mu.Unlock()
+	}
+}
+
+func (h *Handler) HandleLLMStart(ctx context.Context, prompts []string) {
+	for _, prompt := range prompts {
+		h.scan(ctx, HookPrompt, prompt)
+	}
+}
+
+func (h *Handler) HandleLLMGenerateContentStart(ctx context.Context, messages []llms.MessageContent) {
+	for _, message := range messages {
+		h.scan(ctx, HookPrompt, messageText(message))
+	}
+}
+
+func (h *Handler) HandleLLMGenerateContentEnd(ctx context.Context, res *llms.ContentResponse) {
+	if res == nil {
+		return
+	}
+	for _, choice := range res.Choices {
+		if choice != nil {
+			h.scan(ctx, HookOutput, choice.Content)
+		}
+	}
+}
+
+func (h *Handler) HandleToolStart(ctx context.Context, input string) {
+	h.scan(ctx, HookTool, input)
+}
+
+func (h *Handler) HandleToolEnd(ctx context.Context, output string) {
+	h.scan(ctx, HookTool, output)
+}
+
+func (h *Handler) HandleRetrieverEnd(ctx context.Context, query string, documents []schema.Document) {
+	for _, document := range documents {
+		h.scan(ctx, HookRetriever, document.PageContent)
+	}
+}
+
+// messageText concatenates the textual parts of a message; binary and tool
+// parts are not scannable.
+func messageText(message llms.MessageContent) string {
+	text := ""
+	for _, part := range message.Parts {
+		if tc, ok := part.(llms.TextContent); ok {
+			if text != "" {
+				text += "\n"
+			}
+			text += tc.Text
+		}
+	}
+	return text
+}
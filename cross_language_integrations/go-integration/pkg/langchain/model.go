@@ -0,0 +1,85 @@
+package langchain
+
+import (
+	"context"
+
+	"github.com/tmc/langchaingo/llms"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// GuardedModel wraps an llms.Model with hard inline enforcement: unlike
+// the callback handler, a threat verdict here fails the call itself, so
+// nothing reaches the provider (prompts) or the chain (outputs).
+type GuardedModel struct {
+	model    llms.Model
+	detector Detector
+	policy   guard.Policy
+}
+
+var _ llms.Model = (*GuardedModel)(nil)
+
+// NewGuardedModel wraps model so every prompt and response passes through
+// the detector. A nil policy uses guard.DefaultPolicy.
+func NewGuardedModel(model llms.Model, det Detector, policy *guard.Policy) *GuardedModel {
+	p := guard.DefaultPolicy()
+	if policy != nil {
+		p = *policy
+	}
+	return &GuardedModel{model: model, detector: det, policy: p}
+}
+
+// GenerateContent scans the outgoing messages, forwards the call, then
+// scans each response choice. Any blocking verdict fails the call with the
+// violation as the error.
+func (g *GuardedModel) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	for _, message := range messages {
+		if err := g.enforce(ctx, HookPrompt, messageText(message)); err != nil {
+			return nil, err
+		}
+	}
+	res, err := g.model.GenerateContent(ctx, messages, options...)
+	if err != nil {
+		return nil, err
+	}
+	for _, choice := range res.Choices {
+		if choice == nil {
+			continue
+		}
+		if err := g.enforce(ctx, HookOutput, choice.Content); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// Call implements the deprecated single-prompt interface with the same
+// enforcement.
+func (g *GuardedModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	if err := g.enforce(ctx, HookPrompt, prompt); err != nil {
+		return "", err
+	}
+	output, err := llms.GenerateFromSinglePrompt(ctx, g.model, prompt, options...)
+	if err != nil {
+		return "", err
+	}
+	if err := g.enforce(ctx, HookOutput, output); err != nil {
+		return "", err
+	}
+	return output, nil
+}
+
+func (g *GuardedModel) enforce(ctx context.Context, hook Hook, text string) error {
+	if text == "" {
+		return nil
+	}
+	result, err := g.detector.DetectContext(ctx, text)
+	if err != nil {
+		return err
+	}
+	action := g.policy.Evaluate(result)
+	if action == guard.ActionBlock || action == guard.ActionSoftBlock || action == guard.ActionQuarantine {
+		return Violation{Hook: hook, Text: text, Result: result}
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+// Package mcp runs the guard as a Model Context Protocol server over
+// stdio, so agent frameworks that speak MCP can call the detector as a
+// native safety tool. The protocol surface the guard needs is small —
+// initialize, tools/list, tools/call over line-delimited JSON-RPC 2.0 —
+// so it is implemented directly rather than pulling in an SDK.
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/marcusjohansson/trust-go/pkg/eval"
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+const (
+	protocolVersion = "2025-06-18"
+	serverName      = "trust-go-guard"
+	serverVersion   = "1.0.0"
+)
+
+// Server speaks MCP on a reader/writer pair, usually stdin/stdout.
+type Server struct {
+	detector eval.Detector
+}
+
+// NewServer wraps a detector for MCP serving; eval.RegexOnly works for a
+// model-free deployment.
+func NewServer(det eval.Detector) *Server {
+	return &Server{detector: det}
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+const (
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// toolDef mirrors the MCP tool descriptor shape.
+type toolDef struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+}
+
+func textInputSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"text": map[string]any{
+				"type":        "string",
+				"description": "The text to analyze",
+			},
+		},
+		"required": []string{"text"},
+	}
+}
+
+var tools = []toolDef{
+	{
+		Name:        "detect",
+		Description: "Run the full hybrid threat detection pipeline (regex, rules, model) over the text and return the verdict as JSON.",
+		InputSchema: textInputSchema(),
+	},
+	{
+		Name:        "scan",
+		Description: "Run only the fast regex and encoded-payload stages over the text; lower latency, no model call.",
+		InputSchema: textInputSchema(),
+	},
+}
+
+// Serve reads requests until EOF or context cancellation. Notifications
+// (requests without an id) get no response, per JSON-RPC.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	encoder := json.NewEncoder(w)
+
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var req request
+		if err := json.Unmarshal(line, &req); err != nil {
+			continue // not a JSON-RPC message; skip rather than kill the session
+		}
+		resp := s.handle(ctx, &req)
+		if resp == nil {
+			continue
+		}
+		if err := encoder.Encode(resp); err != nil {
+			return fmt.Errorf("writing response: %w", err)
+		}
+	}
+	return scanner.Err()
+}
+
+func (s *Server) handle(ctx context.Context, req *request) *response {
+	if len(req.ID) == 0 {
+		return nil // notification (e.g. notifications/initialized)
+	}
+	switch req.Method {
+	case "initialize":
+		return result(req, map[string]any{
+			"protocolVersion": protocolVersion,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]any{"name": serverName, "version": serverVersion},
+		})
+	case "ping":
+		return result(req, map[string]any{})
+	case "tools/list":
+		return result(req, map[string]any{"tools": tools})
+	case "tools/call":
+		return s.callTool(ctx, req)
+	default:
+		return fail(req, codeMethodNotFound, fmt.Sprintf("method %q not supported", req.Method))
+	}
+}
+
+func (s *Server) callTool(ctx context.Context, req *request) *response {
+	var params struct {
+		Name      string `json:"name"`
+		Arguments struct {
+			Text string `json:"text"`
+		} `json:"arguments"`
+	}
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		return fail(req, codeInvalidParams, "invalid tools/call params")
+	}
+
+	var verdict *guard.ThreatResult
+	var err error
+	switch params.Name {
+	case "detect":
+		verdict, err = s.detector.Detect(params.Arguments.Text)
+	case "scan":
+		verdict, err = eval.RegexOnly{}.Detect(params.Arguments.Text)
+	default:
+		return fail(req, codeInvalidParams, fmt.Sprintf("unknown tool %q", params.Name))
+	}
+	if err != nil {
+		return result(req, map[string]any{
+			"content": []map[string]any{{"type": "text", "text": "detection failed: " + err.Error()}},
+			"isError": true,
+		})
+	}
+
+	payload, err := json.Marshal(verdict)
+	if err != nil {
+		return result(req, map[string]any{
+			"content": []map[string]any{{"type": "text", "text": "encoding verdict failed: " + err.Error()}},
+			"isError": true,
+		})
+	}
+	return result(req, map[string]any{
+		"content": []map[string]any{{"type": "text", "text": string(payload)}},
+		"isError": false,
+	})
+}
+
+func result(req *request, payload any) *response {
+	return &response{JSONRPC: "2.0", ID: req.ID, Result: payload}
+}
+
+func fail(req *request, code int, message string) *response {
+	return &response{JSONRPC: "2.0", ID: req.ID, Error: &rpcError{Code: code, Message: message}}
+}
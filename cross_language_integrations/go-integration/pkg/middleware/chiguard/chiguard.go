@@ -0,0 +1,17 @@
+// Package chiguard binds the guard middleware into Chi. Chi middlewares
+// are plain func(http.Handler) http.Handler, so this is the core package
+// under Chi's naming: r.Use(chiguard.Guard(det, config)) globally, or
+// r.With(chiguard.Guard(det, stricter)) on individual routes.
+package chiguard
+
+import (
+	"net/http"
+
+	"github.com/marcusjohansson/trust-go/pkg/middleware"
+)
+
+// Guard returns a Chi-compatible middleware that scans request bodies and
+// rejects blocked requests.
+func Guard(det middleware.Detector, config middleware.Config) func(http.Handler) http.Handler {
+	return middleware.Guard(det, config)
+}
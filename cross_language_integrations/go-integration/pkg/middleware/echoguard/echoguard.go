@@ -0,0 +1,49 @@
+// Package echoguard binds the guard middleware into Echo. Attach it with
+// e.Use for the whole app or g.Use on a route group for per-route configs.
+package echoguard
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+	"github.com/marcusjohansson/trust-go/pkg/middleware"
+)
+
+// Guard returns an Echo middleware that scans request bodies and rejects
+// blocked requests with the configured rejection response.
+func Guard(det middleware.Detector, config middleware.Config) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if req.Body == nil || req.Body == http.NoBody {
+				return next(c)
+			}
+			body, err := io.ReadAll(req.Body)
+			req.Body.Close()
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "failed to read request body")
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			result, action, err := middleware.Scan(req.Context(), det, config, body)
+			if err != nil {
+				if config.FailOpen {
+					return next(c)
+				}
+				return echo.NewHTTPError(http.StatusServiceUnavailable, "threat detection unavailable")
+			}
+			if middleware.Blocks(action) {
+				middleware.WriteBlocked(c.Response(), req, config, result)
+				return nil
+			}
+			if action == guard.ActionFlag {
+				req.Header.Set(middleware.FlagHeader, string(guard.ActionFlag))
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// Package fiberguard binds the guard middleware into Fiber. Fiber runs on
+// fasthttp rather than net/http, so the body is already buffered and the
+// rejection response is written through Fiber's own API.
+package fiberguard
+
+import (
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+	"github.com/marcusjohansson/trust-go/pkg/middleware"
+)
+
+// Guard returns a Fiber middleware that scans request bodies and rejects
+// blocked requests. OnBlocked from the config is not supported here since
+// Fiber handlers do not receive an http.ResponseWriter; blocked requests
+// always get 403 with the verdict as JSON.
+func Guard(det middleware.Detector, config middleware.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		body := c.Body()
+		if len(body) == 0 {
+			return c.Next()
+		}
+		result, action, err := middleware.Scan(c.UserContext(), det, config, body)
+		if err != nil {
+			if config.FailOpen {
+				return c.Next()
+			}
+			return c.Status(fiber.StatusServiceUnavailable).SendString("threat detection unavailable")
+		}
+		if middleware.Blocks(action) {
+			payload, err := json.Marshal(result)
+			if err != nil {
+				return c.SendStatus(fiber.StatusForbidden)
+			}
+			c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			return c.Status(fiber.StatusForbidden).Send(payload)
+		}
+		if action == guard.ActionFlag {
+			c.Request().Header.Set(middleware.FlagHeader, string(guard.ActionFlag))
+		}
+		return c.Next()
+	}
+}
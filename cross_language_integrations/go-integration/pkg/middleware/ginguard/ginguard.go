@@ -0,0 +1,52 @@
+// Package ginguard binds the guard middleware into Gin. Attach it globally
+// with router.Use or per route group with group.Use; route-specific
+// configs just mean separate Guard instances.
+package ginguard
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+	"github.com/marcusjohansson/trust-go/pkg/middleware"
+)
+
+// Guard returns a Gin middleware that scans request bodies and aborts
+// blocked requests with the configured rejection response.
+func Guard(det middleware.Detector, config middleware.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
+			return
+		}
+		body, err := io.ReadAll(c.Request.Body)
+		c.Request.Body.Close()
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		result, action, err := middleware.Scan(c.Request.Context(), det, config, body)
+		if err != nil {
+			if config.FailOpen {
+				c.Next()
+				return
+			}
+			c.AbortWithStatus(http.StatusServiceUnavailable)
+			return
+		}
+		if middleware.Blocks(action) {
+			middleware.WriteBlocked(c.Writer, c.Request, config, result)
+			c.Abort()
+			return
+		}
+		if action == guard.ActionFlag {
+			c.Request.Header.Set(middleware.FlagHeader, string(guard.ActionFlag))
+		}
+		c.Next()
+	}
+}
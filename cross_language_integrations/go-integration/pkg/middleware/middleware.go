@@ -0,0 +1,128 @@
+// Package middleware guards HTTP APIs at the router level: request bodies
+// are scanned before the handler runs, and the policy decides whether the
+// request proceeds, is flagged, or is rejected. The package itself is plain
+// net/http (which also makes it the Chi adapter); the gin, echo, and fiber
+// subpackages bind the same core into each framework's middleware idiom.
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Detector is the detection entry point the middleware needs; satisfied by
+// *detector.EnhancedDetector.
+type Detector interface {
+	DetectContext(ctx context.Context, text string) (*guard.ThreatResult, error)
+}
+
+// Config tunes one middleware instance; attach differently configured
+// instances to different routes for per-route behavior. The zero value
+// scans bodies up to 1MB against the default policy and fails closed.
+type Config struct {
+	// Policy maps verdicts to actions; nil uses guard.DefaultPolicy.
+	Policy *guard.Policy
+	// MaxBodyBytes caps how much of the body is scanned (default 1MB).
+	// Larger bodies are scanned up to the cap, not skipped.
+	MaxBodyBytes int64
+	// FailOpen lets requests through when the detector itself errors.
+	// Default is fail-closed: detector errors reject with 503.
+	FailOpen bool
+	// OnBlocked, when set, writes the rejection response instead of the
+	// default 403 with the verdict as JSON.
+	OnBlocked func(w http.ResponseWriter, r *http.Request, result *guard.ThreatResult)
+}
+
+// FlagHeader is set on requests whose verdict was flag rather than block,
+// so downstream handlers can apply extra scrutiny without losing traffic.
+const FlagHeader = "X-Guard-Verdict"
+
+const defaultMaxBodyBytes = 1 << 20
+
+func (c Config) policy() guard.Policy {
+	if c.Policy != nil {
+		return *c.Policy
+	}
+	return guard.DefaultPolicy()
+}
+
+func (c Config) maxBody() int64 {
+	if c.MaxBodyBytes > 0 {
+		return c.MaxBodyBytes
+	}
+	return defaultMaxBodyBytes
+}
+
+// Scan runs the detector over body and evaluates the policy. The adapters
+// call it so blocking semantics stay identical across frameworks.
+func Scan(ctx context.Context, det Detector, config Config, body []byte) (*guard.ThreatResult, guard.Action, error) {
+	text := body
+	if int64(len(text)) > config.maxBody() {
+		text = text[:config.maxBody()]
+	}
+	result, err := det.DetectContext(ctx, string(text))
+	if err != nil {
+		return nil, guard.ActionBlock, err
+	}
+	return result, config.policy().Evaluate(result), nil
+}
+
+// Blocks reports whether an action rejects the request outright.
+func Blocks(action guard.Action) bool {
+	return action == guard.ActionBlock || action == guard.ActionSoftBlock || action == guard.ActionQuarantine
+}
+
+// Guard wraps next with body scanning. Requests without a body pass
+// through untouched; scanned bodies are restored for the handler.
+func Guard(det Detector, config Config) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Body == nil || r.Body == http.NoBody {
+				next.ServeHTTP(w, r)
+				return
+			}
+			body, err := io.ReadAll(r.Body)
+			r.Body.Close()
+			if err != nil {
+				http.Error(w, "failed to read request body", http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			result, action, err := Scan(r.Context(), det, config, body)
+			if err != nil {
+				if config.FailOpen {
+					next.ServeHTTP(w, r)
+					return
+				}
+				http.Error(w, "threat detection unavailable", http.StatusServiceUnavailable)
+				return
+			}
+			if Blocks(action) {
+				WriteBlocked(w, r, config, result)
+				return
+			}
+			if action == guard.ActionFlag {
+				r.Header.Set(FlagHeader, string(guard.ActionFlag))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// WriteBlocked writes the rejection response: the configured OnBlocked
+// hook when set, otherwise 403 with the verdict as JSON.
+func WriteBlocked(w http.ResponseWriter, r *http.Request, config Config, result *guard.ThreatResult) {
+	if config.OnBlocked != nil {
+		config.OnBlocked(w, r, result)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,137 @@
+// Package moderation scores model responses for output safety: toxicity,
+// harassment, sexual content, and violence, each 0-1. Output safety is a
+// different problem from input injection detection and gets its own scoring
+// path, thresholds, and actions rather than overloading the threat pipeline.
+package moderation
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Dimension names scored by output moderation.
+const (
+	DimensionToxicity   = "toxicity"
+	DimensionHarassment = "harassment"
+	DimensionSexual     = "sexual"
+	DimensionViolence   = "violence"
+)
+
+// Scores holds one 0-1 score per moderation dimension.
+type Scores map[string]float64
+
+// Max returns the highest-scoring dimension.
+func (s Scores) Max() (dimension string, score float64) {
+	for dim, value := range s {
+		if value > score || dimension == "" {
+			dimension, score = dim, value
+		}
+	}
+	return dimension, score
+}
+
+// Scorer produces moderation scores for a model response. The built-in
+// LexiconScorer is pattern-based; deployments with a moderation model can
+// plug it in behind this interface.
+type Scorer interface {
+	Score(ctx context.Context, text string) (Scores, error)
+}
+
+// lexicon maps each dimension to indicative patterns. Matches accumulate:
+// one hit scores 0.6, each further hit adds 0.15, capped at 0.95.
+var lexicon = map[string][]string{
+	DimensionToxicity: {
+		`(?i)\b(?:idiot|moron|stupid|worthless|pathetic)\b`,
+		`(?i)\b(?:hate|despise)\s+(?:you|them|those\s+people)\b`,
+		`(?i)\bshut\s+up\b`,
+	},
+	DimensionHarassment: {
+		`(?i)\b(?:kill|hurt|find|stalk)\s+(?:yourself|you)\b`,
+		`(?i)\bnobody\s+(?:likes|wants)\s+you\b`,
+		`(?i)\byou\s+(?:deserve|should)\s+(?:to\s+)?(?:suffer|die|fail)\b`,
+	},
+	DimensionSexual: {
+		`(?i)\b(?:explicit|graphic)\s+sexual\b`,
+		`(?i)\bsexual(?:ly)?\s+(?:content|acts?|explicit)\b`,
+		`(?i)\b(?:nude|naked)\s+(?:photos?|images?|pictures?)\b`,
+	},
+	DimensionViolence: {
+		`(?i)\b(?:kill|murder|stab|shoot|strangle)\s+(?:him|her|them|people|someone)\b`,
+		`(?i)\b(?:torture|mutilate|massacre)\b`,
+		`(?i)\bhow\s+to\s+(?:harm|injure|attack)\b`,
+	},
+}
+
+var lexiconCompiled = func() map[string][]*regexp.Regexp {
+	compiled := make(map[string][]*regexp.Regexp, len(lexicon))
+	for dimension, patterns := range lexicon {
+		for _, pattern := range patterns {
+			compiled[dimension] = append(compiled[dimension], regexp.MustCompile(pattern))
+		}
+	}
+	return compiled
+}()
+
+// LexiconScorer is the built-in pattern-based scorer.
+type LexiconScorer struct{}
+
+// Score rates text on every dimension.
+func (LexiconScorer) Score(_ context.Context, text string) (Scores, error) {
+	scores := Scores{}
+	for dimension, patterns := range lexiconCompiled {
+		hits := 0
+		for _, re := range patterns {
+			hits += len(re.FindAllString(text, -1))
+		}
+		score := 0.0
+		if hits > 0 {
+			score = 0.6 + 0.15*float64(hits-1)
+			if score > 0.95 {
+				score = 0.95
+			}
+		}
+		scores[dimension] = score
+	}
+	return scores, nil
+}
+
+// Rule is the threshold and action for one dimension.
+type Rule struct {
+	Threshold float64      `json:"threshold"`
+	Action    guard.Action `json:"action"`
+}
+
+// Policy maps moderation dimensions to their handling, independent of the
+// input threat policy.
+type Policy struct {
+	Rules map[string]Rule `json:"rules"`
+}
+
+// DefaultPolicy blocks high-scoring harassment and violence and flags the
+// rest at moderate scores.
+func DefaultPolicy() Policy {
+	return Policy{Rules: map[string]Rule{
+		DimensionToxicity:   {Threshold: 0.7, Action: guard.ActionFlag},
+		DimensionHarassment: {Threshold: 0.6, Action: guard.ActionBlock},
+		DimensionSexual:     {Threshold: 0.7, Action: guard.ActionFlag},
+		DimensionViolence:   {Threshold: 0.6, Action: guard.ActionBlock},
+	}}
+}
+
+// Evaluate returns the strictest action across all dimensions whose score
+// meets its threshold, and the dimension that triggered it.
+func (p Policy) Evaluate(scores Scores) (guard.Action, string) {
+	action, triggered := guard.ActionAllow, ""
+	for dimension, score := range scores {
+		rule, ok := p.Rules[dimension]
+		if !ok || score < rule.Threshold {
+			continue
+		}
+		if guard.StricterAction(rule.Action, action) {
+			action, triggered = rule.Action, dimension
+		}
+	}
+	return action, triggered
+}
@@ -0,0 +1,230 @@
+package moderation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Vendor moderation backends: teams that already pay for OpenAI moderation
+// or a Perspective-style endpoint can use those signals as ensemble members
+// next to the local lexicon. Each backend normalizes the vendor's category
+// names into this package's dimensions; when several vendor categories map
+// to one dimension, the highest score wins.
+
+// OpenAIModerationScorer calls an OpenAI-compatible /v1/moderations
+// endpoint.
+type OpenAIModerationScorer struct {
+	BaseURL string
+	APIKey  string
+	// Model is optional; the endpoint's default is used when empty.
+	Model  string
+	Client *http.Client
+}
+
+// NewOpenAIModerationScorer creates a scorer for an OpenAI-compatible
+// moderation API.
+func NewOpenAIModerationScorer(baseURL, apiKey string) *OpenAIModerationScorer {
+	return &OpenAIModerationScorer{
+		BaseURL: baseURL,
+		APIKey:  apiKey,
+		Client:  &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// openaiCategoryDimensions maps OpenAI moderation categories onto the
+// internal dimensions.
+var openaiCategoryDimensions = map[string]string{
+	"harassment":             DimensionHarassment,
+	"harassment/threatening": DimensionHarassment,
+	"hate":                   DimensionToxicity,
+	"hate/threatening":       DimensionToxicity,
+	"sexual":                 DimensionSexual,
+	"sexual/minors":          DimensionSexual,
+	"violence":               DimensionViolence,
+	"violence/graphic":       DimensionViolence,
+}
+
+type openaiModerationResponse struct {
+	Results []struct {
+		CategoryScores map[string]float64 `json:"category_scores"`
+	} `json:"results"`
+}
+
+// Score implements Scorer.
+func (s *OpenAIModerationScorer) Score(ctx context.Context, text string) (Scores, error) {
+	payload := map[string]string{"input": text}
+	if s.Model != "" {
+		payload["model"] = s.Model
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		s.BaseURL+"/v1/moderations", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.APIKey)
+	}
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("moderation API returned status: %s", resp.Status)
+	}
+	var decoded openaiModerationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return nil, err
+	}
+	if len(decoded.Results) == 0 {
+		return nil, fmt.Errorf("moderation API returned no results")
+	}
+
+	scores := Scores{}
+	for category, score := range decoded.Results[0].CategoryScores {
+		dimension, ok := openaiCategoryDimensions[category]
+		if !ok {
+			continue
+		}
+		if score > scores[dimension] {
+			scores[dimension] = score
+		}
+	}
+	return scores, nil
+}
+
+// PerspectiveScorer calls a Perspective-style comment analysis endpoint.
+type PerspectiveScorer struct {
+	// Endpoint is the analyze URL, e.g.
+	// https://commentanalyzer.googleapis.com/v1alpha1/comments:analyze
+	Endpoint string
+	APIKey   string
+	Client   *http.Client
+}
+
+// NewPerspectiveScorer creates a scorer for a Perspective-style API.
+func NewPerspectiveScorer(endpoint, apiKey string) *PerspectiveScorer {
+	return &PerspectiveScorer{
+		Endpoint: endpoint,
+		APIKey:   apiKey,
+		Client:   &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// perspectiveAttributeDimensions maps requested Perspective attributes onto
+// the internal dimensions.
+var perspectiveAttributeDimensions = map[string]string{
+	"TOXICITY":          DimensionToxicity,
+	"INSULT":            DimensionHarassment,
+	"THREAT":            DimensionViolence,
+	"SEXUALLY_EXPLICIT": DimensionSexual,
+}
+
+type perspectiveResponse struct {
+	AttributeScores map[string]struct {
+		SummaryScore struct {
+			Value float64 `json:"value"`
+		} `json:"summaryScore"`
+	} `json:"attributeScores"`
+}
+
+// Score implements Scorer.
+func (s *PerspectiveScorer) Score(ctx context.Context, text string) (Scores, error) {
+	requested := map[string]any{}
+	for attribute := range perspectiveAttributeDimensions {
+		requested[attribute] = struct{}{}
+	}
+	request := map[string]any{
+		"comment":             map[string]string{"text": text},
+		"requestedAttributes": requested,
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := s.Endpoint
+	if s.APIKey != "" {
+		endpoint += "?key=" + url.QueryEscape(s.APIKey)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("perspective API returned status: %s", resp.Status)
+	}
+	var decoded perspectiveResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return nil, err
+	}
+
+	scores := Scores{}
+	for attribute, attributeScore := range decoded.AttributeScores {
+		dimension, ok := perspectiveAttributeDimensions[attribute]
+		if !ok {
+			continue
+		}
+		if value := attributeScore.SummaryScore.Value; value > scores[dimension] {
+			scores[dimension] = value
+		}
+	}
+	return scores, nil
+}
+
+// EnsembleScorer combines several scorers, keeping the highest score per
+// dimension. A member failing does not fail the ensemble as long as at
+// least one member answered.
+type EnsembleScorer struct {
+	Members []Scorer
+}
+
+// NewEnsembleScorer combines the given scorers.
+func NewEnsembleScorer(members ...Scorer) *EnsembleScorer {
+	return &EnsembleScorer{Members: members}
+}
+
+// Score implements Scorer.
+func (e *EnsembleScorer) Score(ctx context.Context, text string) (Scores, error) {
+	combined := Scores{}
+	answered := false
+	var firstErr error
+	for _, member := range e.Members {
+		scores, err := member.Score(ctx, text)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		answered = true
+		for dimension, score := range scores {
+			if score > combined[dimension] {
+				combined[dimension] = score
+			}
+		}
+	}
+	if !answered {
+		if firstErr == nil {
+			firstErr = fmt.Errorf("ensemble has no members")
+		}
+		return nil, firstErr
+	}
+	return combined, nil
+}
@@ -0,0 +1,94 @@
+package notify
+
+import (
+	"sync"
+	"time"
+)
+
+// Alert suppression: 500 hits of the same payload in a minute should page
+// once, not 500 times. Events are grouped by input fingerprint and threat
+// category; the first event in a group goes out immediately, duplicates
+// inside the cool-down window are counted, and a summary event is emitted
+// when the window closes.
+
+// suppressionKey groups duplicate events.
+type suppressionKey struct {
+	inputHash  string
+	threatType string
+}
+
+type suppressionWindow struct {
+	first      Event
+	suppressed int
+	timer      *time.Timer
+}
+
+// suppressor tracks open cool-down windows for a notifier.
+type suppressor struct {
+	window time.Duration
+
+	mu   sync.Mutex
+	open map[suppressionKey]*suppressionWindow
+}
+
+// EnableSuppression turns on duplicate-alert suppression with the given
+// cool-down window (0 uses 1m). Call it before the first Notify.
+func (n *Notifier) EnableSuppression(window time.Duration) {
+	if window <= 0 {
+		window = time.Minute
+	}
+	n.suppress = &suppressor{window: window, open: map[suppressionKey]*suppressionWindow{}}
+}
+
+// admit reports whether the event should be delivered now. The first event
+// of a group opens a window and passes; duplicates are absorbed until the
+// window closes, at which point emit receives a summary of what was
+// suppressed.
+func (s *suppressor) admit(event Event, emit func(Event)) bool {
+	key := suppressionKey{inputHash: event.InputHash, threatType: threatTypeOf(event)}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if window, ok := s.open[key]; ok {
+		window.suppressed++
+		return false
+	}
+	window := &suppressionWindow{first: event}
+	window.timer = time.AfterFunc(s.window, func() {
+		s.close(key, emit)
+	})
+	s.open[key] = window
+	return true
+}
+
+// close ends a group's window, emitting a summary when anything was
+// suppressed in it.
+func (s *suppressor) close(key suppressionKey, emit func(Event)) {
+	s.mu.Lock()
+	window, ok := s.open[key]
+	delete(s.open, key)
+	s.mu.Unlock()
+	if !ok || window.suppressed == 0 {
+		return
+	}
+	summary := window.first
+	summary.Timestamp = time.Now()
+	summary.Suppressed = window.suppressed
+	emit(summary)
+}
+
+// stop cancels all open windows without emitting summaries.
+func (s *suppressor) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, window := range s.open {
+		window.timer.Stop()
+		delete(s.open, key)
+	}
+}
+
+func threatTypeOf(event Event) string {
+	if event.Result == nil {
+		return ""
+	}
+	return event.Result.ThreatType
+}
@@ -0,0 +1,170 @@
+// Package notify delivers real-time alerts for blocked high-severity
+// threats to webhook targets, so the SOC hears about incidents without
+// polling logs. Delivery is asynchronous with retry and backoff; a slow or
+// down webhook never blocks the detection path.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Event describes one blocked detection.
+type Event struct {
+	Timestamp time.Time           `json:"timestamp"`
+	InputHash string              `json:"input_hash"`
+	Result    *guard.ThreatResult `json:"result"`
+	// Suppressed is non-zero on summary events: how many duplicates were
+	// absorbed during the suppression window (see EnableSuppression).
+	Suppressed int `json:"suppressed,omitempty"`
+}
+
+// Format selects the webhook payload shape.
+type Format string
+
+const (
+	// FormatJSON POSTs the Event as-is.
+	FormatJSON Format = "json"
+	// FormatSlack POSTs a Slack-compatible {"text": "..."} payload.
+	FormatSlack Format = "slack"
+)
+
+// Webhook is one notification target.
+type Webhook struct {
+	URL    string
+	Format Format
+}
+
+// Notifier fans events out to webhook targets from a background worker.
+type Notifier struct {
+	targets []Webhook
+	client  *http.Client
+	queue   chan Event
+	wg      sync.WaitGroup
+
+	// Retries and Backoff control redelivery: each failed attempt waits
+	// Backoff doubled per retry. They may be set before the first Notify.
+	Retries int
+	Backoff time.Duration
+
+	// suppress, when set, absorbs duplicate events (see EnableSuppression).
+	suppress *suppressor
+	closeMu  sync.Mutex
+	closed   bool
+}
+
+// NewNotifier starts a notifier delivering to the given targets.
+func NewNotifier(targets ...Webhook) *Notifier {
+	n := &Notifier{
+		targets: targets,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		queue:   make(chan Event, 256),
+		Retries: 3,
+		Backoff: time.Second,
+	}
+	n.wg.Add(1)
+	go n.run()
+	return n
+}
+
+// Notify enqueues an event for delivery. It never blocks: when the queue is
+// full the event is dropped rather than stalling detection. With
+// suppression enabled, duplicates inside a cool-down window are absorbed
+// into a later summary event.
+func (n *Notifier) Notify(event Event) {
+	if n.suppress != nil && !n.suppress.admit(event, n.enqueue) {
+		return
+	}
+	n.enqueue(event)
+}
+
+func (n *Notifier) enqueue(event Event) {
+	n.closeMu.Lock()
+	defer n.closeMu.Unlock()
+	if n.closed {
+		return
+	}
+	select {
+	case n.queue <- event:
+	default:
+	}
+}
+
+// Close stops the worker after draining queued events. Open suppression
+// windows are discarded without summaries.
+func (n *Notifier) Close() {
+	if n.suppress != nil {
+		n.suppress.stop()
+	}
+	n.closeMu.Lock()
+	n.closed = true
+	close(n.queue)
+	n.closeMu.Unlock()
+	n.wg.Wait()
+}
+
+func (n *Notifier) run() {
+	defer n.wg.Done()
+	for event := range n.queue {
+		for _, target := range n.targets {
+			n.deliver(target, event)
+		}
+	}
+}
+
+// deliver POSTs one event to one target, retrying with exponential backoff.
+func (n *Notifier) deliver(target Webhook, event Event) {
+	payload, err := marshalPayload(target.Format, event)
+	if err != nil {
+		return
+	}
+	backoff := n.Backoff
+	for attempt := 0; attempt <= n.Retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		if n.post(target.URL, payload) == nil {
+			return
+		}
+	}
+}
+
+func (n *Notifier) post(url string, payload []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+func marshalPayload(format Format, event Event) ([]byte, error) {
+	if format == FormatSlack {
+		text := fmt.Sprintf("Blocked %s threat: %s (confidence %.2f) — %s [input %s]",
+			event.Result.Severity, event.Result.ThreatType, event.Result.Confidence,
+			event.Result.Reasoning, event.InputHash)
+		if event.Suppressed > 0 {
+			text = fmt.Sprintf("%s (+%d duplicates suppressed)", text, event.Suppressed)
+		}
+		return json.Marshal(map[string]string{"text": text})
+	}
+	return json.Marshal(event)
+}
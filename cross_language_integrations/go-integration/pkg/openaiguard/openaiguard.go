@@ -0,0 +1,110 @@
+// Package openaiguard wraps the go-openai client with built-in guarding:
+// CreateChatCompletion scans every outgoing message before the API call
+// and every response choice after it, failing with *GuardBlockedError
+// when the policy blocks. The wrapper embeds the underlying client, so
+// swapping openai.NewClient for openaiguard.NewClient is the whole
+// migration; every other method passes through unguarded.
+package openaiguard
+
+import (
+	"context"
+	"fmt"
+
+	openai "github.com/sashabaranov/go-openai"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Detector is the detection entry point the wrapper needs; satisfied by
+// *detector.EnhancedDetector.
+type Detector interface {
+	DetectContext(ctx context.Context, text string) (*guard.ThreatResult, error)
+}
+
+// GuardBlockedError reports which direction was blocked and why.
+type GuardBlockedError struct {
+	// Direction is "request" or "response".
+	Direction string
+	Result    *guard.ThreatResult
+	Action    guard.Action
+}
+
+func (e *GuardBlockedError) Error() string {
+	return fmt.Sprintf("guard blocked %s: %s (%s, confidence %.2f)",
+		e.Direction, e.Result.ThreatType, e.Result.Severity, e.Result.Confidence)
+}
+
+// Client is a guarded go-openai client.
+type Client struct {
+	*openai.Client
+
+	detector Detector
+	policy   guard.Policy
+}
+
+// NewClient builds a guarded client from an API key. A nil policy uses
+// guard.DefaultPolicy.
+func NewClient(apiKey string, det Detector, policy *guard.Policy) *Client {
+	return Wrap(openai.NewClient(apiKey), det, policy)
+}
+
+// Wrap guards an existing client, preserving any custom configuration
+// (base URL, Azure deployments, proxies).
+func Wrap(client *openai.Client, det Detector, policy *guard.Policy) *Client {
+	p := guard.DefaultPolicy()
+	if policy != nil {
+		p = *policy
+	}
+	return &Client{Client: client, detector: det, policy: p}
+}
+
+// CreateChatCompletion scans request messages, forwards the call, then
+// scans the response choices. Streaming requests must use the underlying
+// client directly and are not guarded.
+func (c *Client) CreateChatCompletion(ctx context.Context, req openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+	for _, message := range req.Messages {
+		if err := c.enforce(ctx, "request", messageText(message)); err != nil {
+			return openai.ChatCompletionResponse{}, err
+		}
+	}
+	resp, err := c.Client.CreateChatCompletion(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	for _, choice := range resp.Choices {
+		if err := c.enforce(ctx, "response", choice.Message.Content); err != nil {
+			return openai.ChatCompletionResponse{}, err
+		}
+	}
+	return resp, nil
+}
+
+func (c *Client) enforce(ctx context.Context, direction, text string) error {
+	if text == "" {
+		return nil
+	}
+	result, err := c.detector.DetectContext(ctx, text)
+	if err != nil {
+		return fmt.Errorf("guard detection failed on %s: %w", direction, err)
+	}
+	action := c.policy.Evaluate(result)
+	if action == guard.ActionBlock || action == guard.ActionSoftBlock || action == guard.ActionQuarantine {
+		return &GuardBlockedError{Direction: direction, Result: result, Action: action}
+	}
+	return nil
+}
+
+// messageText collects the scannable text of a message: the plain content
+// plus any text parts of a multi-content message.
+func messageText(message openai.ChatCompletionMessage) string {
+	text := message.Content
+	for _, part := range message.MultiContent {
+		if part.Type == openai.ChatMessagePartTypeText {
+			if text != "" {
+				text += "\n"
+			}
+			text += part.Text
+		}
+	}
+	return text
+}
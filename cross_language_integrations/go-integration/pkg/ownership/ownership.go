@@ -0,0 +1,117 @@
+// Package ownership maps detections to the teams that own them, so
+// notifications, tickets, and reports land with platform security,
+// trust-and-safety, or fraud instead of a shared queue someone has to
+// triage. The mapping is configurable per deployment.
+package ownership
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Team is one owner team and how to reach it.
+type Team struct {
+	Name string `json:"name"`
+	// Contact is deployment-specific: a Slack channel, email alias, or
+	// ticket queue.
+	Contact string `json:"contact,omitempty"`
+}
+
+// Mapping routes threat categories to owner teams.
+type Mapping struct {
+	// Teams defines the known teams by name.
+	Teams map[string]Team `json:"teams"`
+	// ByType maps threat types to a team name.
+	ByType map[string]string `json:"by_type"`
+	// Default is the team for types without an explicit route.
+	Default string `json:"default"`
+}
+
+// DefaultMapping is a starting point reflecting how these categories
+// typically split across teams.
+func DefaultMapping() Mapping {
+	teams := map[string]Team{
+		"platform-security": {Name: "platform-security"},
+		"trust-and-safety":  {Name: "trust-and-safety"},
+		"fraud":             {Name: "fraud"},
+	}
+	byType := map[string]string{
+		"auth_bypass":            "platform-security",
+		"code_injection":         "platform-security",
+		"privilege_escalation":   "platform-security",
+		"data_exfiltration":      "platform-security",
+		"system_prompt_attack":   "platform-security",
+		"session_hijacking":      "platform-security",
+		"man_in_the_middle":      "platform-security",
+		"information_disclosure": "platform-security",
+		"dos_attack":             "platform-security",
+		"resource_exhaustion":    "platform-security",
+		"toxic_content":          "trust-and-safety",
+		"jailbreak":              "trust-and-safety",
+		"prompt_injection":       "trust-and-safety",
+		"content_manipulation":   "trust-and-safety",
+		"context_manipulation":   "trust-and-safety",
+		"output_manipulation":    "trust-and-safety",
+		"business_logic_abuse":   "fraud",
+	}
+	return Mapping{Teams: teams, ByType: byType, Default: "platform-security"}
+}
+
+// LoadMapping reads a mapping from a JSON config file.
+func LoadMapping(path string) (Mapping, error) {
+	var mapping Mapping
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return mapping, err
+	}
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		return mapping, err
+	}
+	return mapping, nil
+}
+
+// Route returns the owner team for a detection's primary threat type.
+func (m Mapping) Route(result *guard.ThreatResult) Team {
+	if result == nil {
+		return m.team(m.Default)
+	}
+	return m.team(m.teamName(result.ThreatType))
+}
+
+// Tags returns the owner team names across the primary type and every
+// secondary label, deduplicated, for multi-team detections.
+func (m Mapping) Tags(result *guard.ThreatResult) []string {
+	if result == nil {
+		return nil
+	}
+	seen := map[string]bool{}
+	var tags []string
+	add := func(threatType string) {
+		name := m.teamName(threatType)
+		if !seen[name] {
+			seen[name] = true
+			tags = append(tags, name)
+		}
+	}
+	add(result.ThreatType)
+	for _, label := range result.Secondary {
+		add(label.ThreatType)
+	}
+	return tags
+}
+
+func (m Mapping) teamName(threatType string) string {
+	if name, ok := m.ByType[threatType]; ok {
+		return name
+	}
+	return m.Default
+}
+
+func (m Mapping) team(name string) Team {
+	if team, ok := m.Teams[name]; ok {
+		return team
+	}
+	return Team{Name: name}
+}
@@ -0,0 +1,200 @@
+// Package pii provides structured detectors for personally identifiable
+// information, with validation (Luhn, IBAN mod-97, SSN area rules) to keep
+// false positives down, plus redaction for scrubbing text before it reaches
+// the model.
+package pii
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Finding is one PII occurrence in the scanned text.
+type Finding struct {
+	// Type is one of "ssn", "credit_card", "email", "phone", "iban".
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+var (
+	ssnPattern   = regexp.MustCompile(`\b(\d{3})[-\s]?(\d{2})[-\s]?(\d{4})\b`)
+	cardPattern  = regexp.MustCompile(`\b(?:\d[ -]?){13,19}\b`)
+	emailPattern = regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`)
+	phonePattern = regexp.MustCompile(`(?:\+\d{1,3}[\s.-]?)?(?:\(\d{2,4}\)[\s.-]?)?\d{3}[\s.-]\d{3,4}[\s.-]?\d{0,4}\b|\b\d{10,11}\b`)
+	ibanPattern  = regexp.MustCompile(`\b[A-Z]{2}\d{2}[A-Z0-9]{11,30}\b`)
+)
+
+// Detect returns all validated PII findings in the text, ordered by position.
+func Detect(text string) []Finding {
+	var findings []Finding
+
+	for _, loc := range ssnPattern.FindAllStringSubmatchIndex(text, -1) {
+		candidate := text[loc[0]:loc[1]]
+		if validSSN(text[loc[2]:loc[3]], text[loc[4]:loc[5]], text[loc[6]:loc[7]]) {
+			findings = append(findings, Finding{Type: "ssn", Value: candidate, Start: loc[0], End: loc[1]})
+		}
+	}
+	for _, loc := range cardPattern.FindAllStringIndex(text, -1) {
+		candidate := text[loc[0]:loc[1]]
+		if validLuhn(candidate) {
+			findings = append(findings, Finding{Type: "credit_card", Value: candidate, Start: loc[0], End: loc[1]})
+		}
+	}
+	for _, loc := range emailPattern.FindAllStringIndex(text, -1) {
+		findings = append(findings, Finding{Type: "email", Value: text[loc[0]:loc[1]], Start: loc[0], End: loc[1]})
+	}
+	for _, loc := range phonePattern.FindAllStringIndex(text, -1) {
+		candidate := text[loc[0]:loc[1]]
+		if digits := countDigits(candidate); digits >= 7 && digits <= 15 && !overlaps(findings, loc[0], loc[1]) {
+			findings = append(findings, Finding{Type: "phone", Value: candidate, Start: loc[0], End: loc[1]})
+		}
+	}
+	for _, loc := range ibanPattern.FindAllStringIndex(text, -1) {
+		candidate := text[loc[0]:loc[1]]
+		if validIBAN(candidate) {
+			findings = append(findings, Finding{Type: "iban", Value: candidate, Start: loc[0], End: loc[1]})
+		}
+	}
+
+	sort.Slice(findings, func(i, j int) bool { return findings[i].Start < findings[j].Start })
+	return findings
+}
+
+// Redact returns the text with every finding replaced by a [REDACTED:type]
+// placeholder, alongside the findings. Use it to scrub inputs before they
+// reach the model.
+func Redact(text string) (string, []Finding) {
+	findings := Detect(text)
+	if len(findings) == 0 {
+		return text, nil
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, f := range findings {
+		if f.Start < last {
+			continue // overlapping finding already redacted
+		}
+		sb.WriteString(text[last:f.Start])
+		sb.WriteString("[REDACTED:" + f.Type + "]")
+		last = f.End
+	}
+	sb.WriteString(text[last:])
+	return sb.String(), findings
+}
+
+// Check returns a ThreatResult when the text contains PII, nil otherwise.
+func Check(text string) *guard.ThreatResult {
+	findings := Detect(text)
+	if len(findings) == 0 {
+		return nil
+	}
+	types := map[string]int{}
+	for _, f := range findings {
+		types[f.Type]++
+	}
+	parts := make([]string, 0, len(types))
+	for _, t := range []string{"ssn", "credit_card", "iban", "email", "phone"} {
+		if types[t] > 0 {
+			parts = append(parts, fmt.Sprintf("%s x%d", t, types[t]))
+		}
+	}
+	confidence := 0.7
+	if types["ssn"] > 0 || types["credit_card"] > 0 || types["iban"] > 0 {
+		confidence = 0.95
+	}
+	return &guard.ThreatResult{
+		IsThreat:   true,
+		ThreatType: "data_exfiltration",
+		Confidence: confidence,
+		Reasoning:  "PII detected: " + strings.Join(parts, ", "),
+		Severity:   guard.SeverityForType("data_exfiltration"),
+	}
+}
+
+// validSSN applies SSA issuance rules: area not 000/666/900-999, group not
+// 00, serial not 0000.
+func validSSN(area, group, serial string) bool {
+	if area == "000" || area == "666" || area[0] == '9' {
+		return false
+	}
+	if group == "00" || serial == "0000" {
+		return false
+	}
+	return true
+}
+
+// validLuhn checks the Luhn checksum over the digits of the candidate.
+func validLuhn(candidate string) bool {
+	digits := make([]int, 0, len(candidate))
+	for _, r := range candidate {
+		if r >= '0' && r <= '9' {
+			digits = append(digits, int(r-'0'))
+		}
+	}
+	if len(digits) < 13 || len(digits) > 19 {
+		return false
+	}
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := digits[i]
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// validIBAN checks length and the ISO 7064 mod-97 checksum.
+func validIBAN(candidate string) bool {
+	if len(candidate) < 15 || len(candidate) > 34 {
+		return false
+	}
+	rearranged := candidate[4:] + candidate[:4]
+	remainder := 0
+	for _, r := range rearranged {
+		var v int
+		switch {
+		case r >= '0' && r <= '9':
+			v = int(r - '0')
+			remainder = (remainder*10 + v) % 97
+		case r >= 'A' && r <= 'Z':
+			v = int(r-'A') + 10
+			remainder = (remainder*100 + v) % 97
+		default:
+			return false
+		}
+	}
+	return remainder == 1
+}
+
+func countDigits(s string) int {
+	n := 0
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			n++
+		}
+	}
+	return n
+}
+
+func overlaps(findings []Finding, start, end int) bool {
+	for _, f := range findings {
+		if start < f.End && end > f.Start {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+// Package quarantine provides the middle path between block and allow:
+// medium-confidence inputs are held in a queue until a human approves or
+// rejects them. Support workflows resolve items through the queue API and
+// callers poll (or get called back) for the released verdict.
+package quarantine
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Status is an item's position in the review workflow.
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusApproved Status = "approved"
+	StatusRejected Status = "rejected"
+)
+
+// Item is one quarantined input awaiting review.
+type Item struct {
+	ID        string              `json:"id"`
+	Timestamp time.Time           `json:"timestamp"`
+	Tenant    string              `json:"tenant,omitempty"`
+	Input     string              `json:"input"`
+	Result    *guard.ThreatResult `json:"result"`
+	Status    Status              `json:"status"`
+	// ResolvedAt is set when a reviewer approves or rejects the item.
+	ResolvedAt time.Time `json:"resolved_at,omitempty"`
+}
+
+// Queue holds quarantined items for human review. Implementations must be
+// safe for concurrent use.
+type Queue interface {
+	// Enqueue stores a new pending item and returns its ID.
+	Enqueue(ctx context.Context, item Item) (string, error)
+	// Get returns one item by ID.
+	Get(ctx context.Context, id string) (Item, error)
+	// Pending lists unresolved items, oldest first.
+	Pending(ctx context.Context) ([]Item, error)
+	// Resolve approves or rejects a pending item and returns its final
+	// state. Resolving an already-resolved item is an error.
+	Resolve(ctx context.Context, id string, approve bool) (Item, error)
+}
+
+// NewID returns a fresh quarantine item ID.
+func NewID() string {
+	buf := make([]byte, 8)
+	rand.Read(buf)
+	return "Q-" + hex.EncodeToString(buf)
+}
+
+// MemoryQueue is the in-memory Queue, for single-instance deployments.
+type MemoryQueue struct {
+	mu    sync.Mutex
+	items map[string]Item
+}
+
+// NewMemoryQueue creates an empty in-memory queue.
+func NewMemoryQueue() *MemoryQueue {
+	return &MemoryQueue{items: map[string]Item{}}
+}
+
+// Enqueue implements Queue.
+func (q *MemoryQueue) Enqueue(_ context.Context, item Item) (string, error) {
+	if item.ID == "" {
+		item.ID = NewID()
+	}
+	if item.Timestamp.IsZero() {
+		item.Timestamp = time.Now()
+	}
+	item.Status = StatusPending
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.items[item.ID] = item
+	return item.ID, nil
+}
+
+// Get implements Queue.
+func (q *MemoryQueue) Get(_ context.Context, id string) (Item, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	item, ok := q.items[id]
+	if !ok {
+		return Item{}, fmt.Errorf("no quarantined item %q", id)
+	}
+	return item, nil
+}
+
+// Pending implements Queue.
+func (q *MemoryQueue) Pending(_ context.Context) ([]Item, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var pending []Item
+	for _, item := range q.items {
+		if item.Status == StatusPending {
+			pending = append(pending, item)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].Timestamp.Before(pending[j].Timestamp)
+	})
+	return pending, nil
+}
+
+// Resolve implements Queue.
+func (q *MemoryQueue) Resolve(_ context.Context, id string, approve bool) (Item, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	item, ok := q.items[id]
+	if !ok {
+		return Item{}, fmt.Errorf("no quarantined item %q", id)
+	}
+	if item.Status != StatusPending {
+		return Item{}, fmt.Errorf("item %q already %s", id, item.Status)
+	}
+	if approve {
+		item.Status = StatusApproved
+	} else {
+		item.Status = StatusRejected
+	}
+	item.ResolvedAt = time.Now()
+	q.items[id] = item
+	return item, nil
+}
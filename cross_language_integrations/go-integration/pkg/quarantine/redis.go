@@ -0,0 +1,130 @@
+package quarantine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisQueue stores quarantined items in Redis so several guard instances
+// and the review dashboard share one queue. Items live as JSON values under
+// a key prefix; pending IDs sit in a sorted set scored by enqueue time.
+type RedisQueue struct {
+	client *redis.Client
+	prefix string
+	// TTL bounds how long resolved items are kept (0 keeps them forever).
+	TTL time.Duration
+}
+
+// NewRedisQueue creates a queue on the given Redis client. prefix
+// namespaces the keys (empty uses "guard:quarantine").
+func NewRedisQueue(client *redis.Client, prefix string) *RedisQueue {
+	if prefix == "" {
+		prefix = "guard:quarantine"
+	}
+	return &RedisQueue{client: client, prefix: prefix}
+}
+
+func (q *RedisQueue) itemKey(id string) string {
+	return q.prefix + ":item:" + id
+}
+
+func (q *RedisQueue) pendingKey() string {
+	return q.prefix + ":pending"
+}
+
+// Enqueue implements Queue.
+func (q *RedisQueue) Enqueue(ctx context.Context, item Item) (string, error) {
+	if item.ID == "" {
+		item.ID = NewID()
+	}
+	if item.Timestamp.IsZero() {
+		item.Timestamp = time.Now()
+	}
+	item.Status = StatusPending
+	if err := q.store(ctx, item, 0); err != nil {
+		return "", err
+	}
+	err := q.client.ZAdd(ctx, q.pendingKey(), redis.Z{
+		Score:  float64(item.Timestamp.UnixNano()),
+		Member: item.ID,
+	}).Err()
+	if err != nil {
+		return "", fmt.Errorf("quarantine enqueue: %w", err)
+	}
+	return item.ID, nil
+}
+
+// Get implements Queue.
+func (q *RedisQueue) Get(ctx context.Context, id string) (Item, error) {
+	data, err := q.client.Get(ctx, q.itemKey(id)).Bytes()
+	if err == redis.Nil {
+		return Item{}, fmt.Errorf("no quarantined item %q", id)
+	}
+	if err != nil {
+		return Item{}, fmt.Errorf("quarantine get: %w", err)
+	}
+	var item Item
+	if err := json.Unmarshal(data, &item); err != nil {
+		return Item{}, fmt.Errorf("corrupt quarantined item %q: %w", id, err)
+	}
+	return item, nil
+}
+
+// Pending implements Queue.
+func (q *RedisQueue) Pending(ctx context.Context) ([]Item, error) {
+	ids, err := q.client.ZRange(ctx, q.pendingKey(), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("quarantine pending: %w", err)
+	}
+	items := make([]Item, 0, len(ids))
+	for _, id := range ids {
+		item, err := q.Get(ctx, id)
+		if err != nil {
+			// An item expired or was removed between ZRange and Get; drop
+			// it from the pending set rather than failing the listing.
+			q.client.ZRem(ctx, q.pendingKey(), id)
+			continue
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// Resolve implements Queue.
+func (q *RedisQueue) Resolve(ctx context.Context, id string, approve bool) (Item, error) {
+	item, err := q.Get(ctx, id)
+	if err != nil {
+		return Item{}, err
+	}
+	if item.Status != StatusPending {
+		return Item{}, fmt.Errorf("item %q already %s", id, item.Status)
+	}
+	if approve {
+		item.Status = StatusApproved
+	} else {
+		item.Status = StatusRejected
+	}
+	item.ResolvedAt = time.Now()
+	if err := q.store(ctx, item, q.TTL); err != nil {
+		return Item{}, err
+	}
+	if err := q.client.ZRem(ctx, q.pendingKey(), id).Err(); err != nil {
+		return Item{}, fmt.Errorf("quarantine resolve: %w", err)
+	}
+	return item, nil
+}
+
+func (q *RedisQueue) store(ctx context.Context, item Item, ttl time.Duration) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	if err := q.client.Set(ctx, q.itemKey(item.ID), data, ttl).Err(); err != nil {
+		return fmt.Errorf("quarantine store: %w", err)
+	}
+	return nil
+}
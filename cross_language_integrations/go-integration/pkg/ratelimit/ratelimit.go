@@ -0,0 +1,214 @@
+// Package ratelimit tracks detection attempts per caller and escalates when
+// a caller keeps triggering threats. A single request is judged in
+// isolation by the detector; this package is the memory across requests
+// that takes unlimited tries away from a brute-forcing attacker: repeated
+// threat verdicts first tighten the caller's policy thresholds, then ban
+// the caller temporarily.
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Standing is a caller's current treatment.
+type Standing string
+
+const (
+	// StandingOK is the normal case: full rate, default thresholds.
+	StandingOK Standing = "ok"
+	// StandingRestricted means the caller accumulated threat verdicts and
+	// is judged under tightened thresholds (see TightenPolicy).
+	StandingRestricted Standing = "restricted"
+	// StandingBanned means the caller is rejected outright until the ban
+	// expires.
+	StandingBanned Standing = "banned"
+)
+
+// Config tunes the limiter. Zero values use the defaults.
+type Config struct {
+	// RequestsPerMinute caps detection attempts per caller (default 120).
+	RequestsPerMinute int
+	// RestrictScore is the abuse score at which thresholds tighten
+	// (default 3, roughly three high-severity hits).
+	RestrictScore float64
+	// BanScore is the abuse score at which a temporary ban starts
+	// (default 10).
+	BanScore float64
+	// BanDuration is how long a ban lasts (default 15m).
+	BanDuration time.Duration
+	// HalfLife is the abuse score's exponential decay half-life
+	// (default 10m), so a caller with one bad afternoon recovers.
+	HalfLife time.Duration
+	// ThresholdDelta is subtracted from policy thresholds for restricted
+	// callers (default 0.2).
+	ThresholdDelta float64
+}
+
+func (c Config) withDefaults() Config {
+	if c.RequestsPerMinute <= 0 {
+		c.RequestsPerMinute = 120
+	}
+	if c.RestrictScore <= 0 {
+		c.RestrictScore = 3
+	}
+	if c.BanScore <= 0 {
+		c.BanScore = 10
+	}
+	if c.BanDuration <= 0 {
+		c.BanDuration = 15 * time.Minute
+	}
+	if c.HalfLife <= 0 {
+		c.HalfLife = 10 * time.Minute
+	}
+	if c.ThresholdDelta <= 0 {
+		c.ThresholdDelta = 0.2
+	}
+	return c
+}
+
+// scoreWeights scale each threat verdict's contribution to the abuse score.
+var scoreWeights = map[guard.Severity]float64{
+	guard.SeverityLow:      0.25,
+	guard.SeverityMedium:   0.5,
+	guard.SeverityHigh:     1.0,
+	guard.SeverityCritical: 2.0,
+}
+
+// Decision is the limiter's answer for one attempt.
+type Decision struct {
+	// Allowed is false when the caller is banned or over its request rate.
+	Allowed  bool
+	Standing Standing
+	// RetryAfter says when a denied caller may try again.
+	RetryAfter time.Duration
+	// Score is the caller's current abuse score.
+	Score float64
+}
+
+type callerState struct {
+	windowStart time.Time
+	count       int
+	score       float64
+	scoredAt    time.Time
+	bannedUntil time.Time
+}
+
+// Limiter keys request caps and abuse scores by caller (user or session
+// ID). It is safe for concurrent use.
+type Limiter struct {
+	config Config
+
+	mu      sync.Mutex
+	callers map[string]*callerState
+}
+
+// NewLimiter creates a limiter with the given config.
+func NewLimiter(config Config) *Limiter {
+	return &Limiter{
+		config:  config.withDefaults(),
+		callers: make(map[string]*callerState),
+	}
+}
+
+// Check records one detection attempt and returns whether it may proceed.
+// Call it before running the pipeline.
+func (l *Limiter) Check(caller string) Decision {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	state := l.state(caller, now)
+
+	if now.Before(state.bannedUntil) {
+		return Decision{
+			Standing:   StandingBanned,
+			RetryAfter: state.bannedUntil.Sub(now),
+			Score:      state.score,
+		}
+	}
+	if now.Sub(state.windowStart) >= time.Minute {
+		state.windowStart = now
+		state.count = 0
+	}
+	if state.count >= l.config.RequestsPerMinute {
+		return Decision{
+			Standing:   l.standing(state),
+			RetryAfter: state.windowStart.Add(time.Minute).Sub(now),
+			Score:      state.score,
+		}
+	}
+	state.count++
+	return Decision{Allowed: true, Standing: l.standing(state), Score: state.score}
+}
+
+// Record feeds a detection verdict back into the caller's abuse score.
+// Benign results leave the score untouched (it still decays over time).
+func (l *Limiter) Record(caller string, result *guard.ThreatResult) {
+	if result == nil || !result.IsThreat {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	state := l.state(caller, now)
+	state.score += scoreWeights[guard.SeverityForType(result.ThreatType)]
+	if state.score >= l.config.BanScore && !now.Before(state.bannedUntil) {
+		state.bannedUntil = now.Add(l.config.BanDuration)
+	}
+}
+
+// Standing returns the caller's current standing without counting an
+// attempt.
+func (l *Limiter) Standing(caller string) Standing {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	state := l.state(caller, now)
+	if now.Before(state.bannedUntil) {
+		return StandingBanned
+	}
+	return l.standing(state)
+}
+
+// TightenPolicy returns a copy of policy with every threshold lowered by
+// the configured delta, so restricted callers are blocked at lower
+// confidence. Thresholds never drop below zero.
+func (l *Limiter) TightenPolicy(policy guard.Policy) guard.Policy {
+	tightened := policy
+	tightened.Rules = make(map[string]guard.PolicyRule, len(policy.Rules))
+	for tType, rule := range policy.Rules {
+		rule.Threshold = math.Max(0, rule.Threshold-l.config.ThresholdDelta)
+		tightened.Rules[tType] = rule
+	}
+	tightened.DefaultRule.Threshold = math.Max(0, policy.DefaultRule.Threshold-l.config.ThresholdDelta)
+	return tightened
+}
+
+// state fetches (or creates) a caller's state with its score decayed to
+// now. Callers must hold l.mu.
+func (l *Limiter) state(caller string, now time.Time) *callerState {
+	state, ok := l.callers[caller]
+	if !ok {
+		state = &callerState{windowStart: now, scoredAt: now}
+		l.callers[caller] = state
+		return state
+	}
+	if elapsed := now.Sub(state.scoredAt); elapsed > 0 && state.score > 0 {
+		state.score *= math.Pow(0.5, elapsed.Seconds()/l.config.HalfLife.Seconds())
+		if state.score < 0.01 {
+			state.score = 0
+		}
+	}
+	state.scoredAt = now
+	return state
+}
+
+func (l *Limiter) standing(state *callerState) Standing {
+	if state.score >= l.config.RestrictScore {
+		return StandingRestricted
+	}
+	return StandingOK
+}
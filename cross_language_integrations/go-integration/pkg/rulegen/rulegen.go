@@ -0,0 +1,143 @@
+// Package rulegen compiles the canonical Go rule bundle into equivalent
+// artifacts for the other language integrations (a JS RegExp module for the
+// TS integration, a Python re module), so their fast paths are generated
+// from one source of truth instead of hand-maintained copies.
+package rulegen
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Warning flags a pattern construct that does not translate cleanly to the
+// target runtime. Warned patterns are omitted from the artifact rather than
+// emitted with silently different semantics.
+type Warning struct {
+	ThreatType string `json:"threat_type"`
+	Pattern    string `json:"pattern"`
+	Target     string `json:"target"`
+	Construct  string `json:"construct"`
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: pattern %q (%s) uses unsupported construct: %s",
+		w.Target, w.Pattern, w.ThreatType, w.Construct)
+}
+
+var (
+	posixClassRe  = regexp.MustCompile(`\[\[:\w+:\]\]`)
+	namedGroupRe  = regexp.MustCompile(`\(\?P<(\w+)>`)
+	inlineFlagsRe = regexp.MustCompile(`\(\?[imsU]+[):]`)
+	unicodePropRe = regexp.MustCompile(`\\[pP]\{`)
+)
+
+// CompileJS renders the rule bundle as an ES module exporting RULES, a map
+// of threat type to an array of case-insensitive RegExp literals.
+func CompileJS(patterns map[string][]string) (string, []Warning) {
+	var b strings.Builder
+	var warnings []Warning
+	b.WriteString("// Code generated by rulegen from the Go rule bundle. DO NOT EDIT.\n\n")
+	b.WriteString("export const RULES = {\n")
+	for _, tType := range sortedTypes(patterns) {
+		b.WriteString(fmt.Sprintf("  %q: [\n", tType))
+		for _, pattern := range patterns[tType] {
+			// A leading (?i) lifts into the /i flag; any other inline flag
+			// group has no JS equivalent.
+			body := strings.TrimPrefix(pattern, "(?i)")
+			if construct := unsupportedJS(body); construct != "" {
+				warnings = append(warnings, Warning{
+					ThreatType: tType, Pattern: pattern, Target: "js", Construct: construct,
+				})
+				continue
+			}
+			// JS named-group syntax drops the Python-style P.
+			translated := namedGroupRe.ReplaceAllString(body, "(?<$1>")
+			b.WriteString("    /" + strings.ReplaceAll(translated, "/", `\/`) + "/i,\n")
+		}
+		b.WriteString("  ],\n")
+	}
+	b.WriteString("};\n")
+	return b.String(), warnings
+}
+
+// CompilePython renders the rule bundle as a Python module defining RULES, a
+// dict of threat type to pre-compiled case-insensitive patterns.
+func CompilePython(patterns map[string][]string) (string, []Warning) {
+	var b strings.Builder
+	var warnings []Warning
+	b.WriteString("# Code generated by rulegen from the Go rule bundle. DO NOT EDIT.\n\n")
+	b.WriteString("import re\n\nRULES = {\n")
+	for _, tType := range sortedTypes(patterns) {
+		b.WriteString(fmt.Sprintf("    %q: [\n", tType))
+		for _, pattern := range patterns[tType] {
+			// re.IGNORECASE replaces a leading (?i).
+			body := strings.TrimPrefix(pattern, "(?i)")
+			if construct := unsupportedPython(body); construct != "" {
+				warnings = append(warnings, Warning{
+					ThreatType: tType, Pattern: pattern, Target: "python", Construct: construct,
+				})
+				continue
+			}
+			b.WriteString("        re.compile(" + pythonString(body) + ", re.IGNORECASE),\n")
+		}
+		b.WriteString("    ],\n")
+	}
+	b.WriteString("}\n")
+	return b.String(), warnings
+}
+
+func sortedTypes(patterns map[string][]string) []string {
+	types := make([]string, 0, len(patterns))
+	for tType := range patterns {
+		types = append(types, tType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// unsupportedJS reports the first construct in pattern that a JS RegExp
+// literal cannot express, or "".
+func unsupportedJS(pattern string) string {
+	if _, err := regexp.Compile("(?i)" + pattern); err != nil {
+		return "does not compile in Go: " + err.Error()
+	}
+	if posixClassRe.MatchString(pattern) {
+		return "POSIX character class"
+	}
+	if inlineFlagsRe.MatchString(pattern) {
+		return "inline flag group"
+	}
+	if unicodePropRe.MatchString(pattern) {
+		return `\p{...} requires the u flag`
+	}
+	return ""
+}
+
+// unsupportedPython reports the first construct in pattern that Python's re
+// module cannot express, or "".
+func unsupportedPython(pattern string) string {
+	if _, err := regexp.Compile("(?i)" + pattern); err != nil {
+		return "does not compile in Go: " + err.Error()
+	}
+	if posixClassRe.MatchString(pattern) {
+		return "POSIX character class"
+	}
+	if unicodePropRe.MatchString(pattern) {
+		return `\p{...} unicode property`
+	}
+	return ""
+}
+
+// pythonString quotes pattern as a Python string literal, preferring a raw
+// string when possible. Patterns containing double quotes or ending with a
+// backslash fall back to an escaped plain string.
+func pythonString(pattern string) string {
+	if !strings.Contains(pattern, `"`) && !strings.HasSuffix(pattern, `\`) {
+		return `r"` + pattern + `"`
+	}
+	escaped := strings.ReplaceAll(pattern, `\`, `\\`)
+	escaped = strings.ReplaceAll(escaped, `"`, `\"`)
+	return `"` + escaped + `"`
+}
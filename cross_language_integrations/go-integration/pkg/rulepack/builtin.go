@@ -0,0 +1,139 @@
+package rulepack
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// First-party rule packs for common verticals, loadable by name in config:
+//
+//	rulepack.ApplyBuiltin("healthcare", "finance")
+//
+// Each pack adds domain categories and tunes thresholds for types that
+// matter more in that vertical.
+
+var builtin = map[string]*Pack{
+	"healthcare": {
+		FormatVersion: FormatVersion,
+		Name:          "healthcare",
+		Version:       "1.0.0",
+		Description:   "HIPAA identifiers and healthcare data-handling abuse",
+		Taxonomy: map[string]guard.Severity{
+			"hipaa_identifier": guard.SeverityCritical,
+		},
+		Rules: map[string][]string{
+			"hipaa_identifier": {
+				`(?:medical\s+record|mrn)[\s#:]+\d{6,10}`,
+				`\bnpi[\s#:]+\d{10}\b`,
+				`\bdea[\s#:]+[a-z]{2}\d{7}\b`,
+				`(?:health\s+plan|member|beneficiary)\s+(?:id|number)[\s#:]+\w{6,}`,
+				`(?:diagnosis|icd-?10)\s+code\s+for\s+(?:patient|member)`,
+			},
+			"data_exfiltration": {
+				`(?:list|export|dump)\s+(?:all\s+)?patient\s+(?:records?|data|charts?)`,
+			},
+		},
+		PolicyRules: map[string]guard.PolicyRule{
+			"hipaa_identifier":  {Threshold: 0.4, Action: guard.ActionBlock},
+			"data_exfiltration": {Threshold: 0.4, Action: guard.ActionBlock},
+		},
+	},
+	"finance": {
+		FormatVersion: FormatVersion,
+		Name:          "finance",
+		Version:       "1.0.0",
+		Description:   "Payment fraud and financial data exfiltration",
+		Taxonomy: map[string]guard.Severity{
+			"finance_fraud": guard.SeverityHigh,
+		},
+		Rules: map[string][]string{
+			"finance_fraud": {
+				`(?:wire|transfer)\s+(?:the\s+)?(?:funds?|money|balance)\s+to`,
+				`(?:routing|aba)\s+number[\s#:]+\d{9}`,
+				`swift\s+(?:code|bic)[\s#:]+[a-z]{6}\w{2,5}`,
+				`(?:chargeback|dispute)\s+(?:scam|abuse|loophole)`,
+				`(?:money\s+mule|structuring|smurfing)`,
+			},
+		},
+		PolicyRules: map[string]guard.PolicyRule{
+			"finance_fraud":        {Threshold: 0.5, Action: guard.ActionBlock},
+			"business_logic_abuse": {Threshold: 0.5, Action: guard.ActionBlock},
+		},
+	},
+	"customer-support": {
+		FormatVersion: FormatVersion,
+		Name:          "customer-support",
+		Version:       "1.0.0",
+		Description:   "Abuse patterns aimed at customer support bots",
+		Taxonomy: map[string]guard.Severity{
+			"support_abuse": guard.SeverityMedium,
+		},
+		Rules: map[string][]string{
+			"support_abuse": {
+				`(?:i\s+am|this\s+is)\s+(?:a\s+)?(?:supervisor|manager|employee)\s+(?:override|access)`,
+				`(?:apply|stack)\s+(?:all|every|multiple)\s+(?:coupons?|promo\s+codes?|discounts?)`,
+				`refund\s+(?:me\s+)?(?:or|otherwise)\s+(?:i\s+will|i'll)`,
+				`escalate\s+(?:this\s+)?(?:to|past)\s+(?:a\s+)?human\s+(?:agent|rep)`,
+			},
+		},
+		PolicyRules: map[string]guard.PolicyRule{
+			"support_abuse": {Threshold: 0.6, Action: guard.ActionFlag},
+		},
+	},
+	"code-assistant": {
+		FormatVersion: FormatVersion,
+		Name:          "code-assistant",
+		Version:       "1.0.0",
+		Description:   "Dangerous code-generation requests for code assistants",
+		Taxonomy: map[string]guard.Severity{
+			"unsafe_code_request": guard.SeverityHigh,
+		},
+		Rules: map[string][]string{
+			"unsafe_code_request": {
+				`disable\s+(?:ssl|tls|certificate)\s+(?:verification|validation|checks?)`,
+				`hard-?code\s+(?:the\s+)?(?:credentials?|password|api\s+key)`,
+				`curl\s+[^\n|]*\|\s*(?:sudo\s+)?(?:ba)?sh`,
+				`(?:reverse|bind)\s+shell`,
+				`chmod\s+777`,
+			},
+		},
+		PolicyRules: map[string]guard.PolicyRule{
+			"unsafe_code_request": {Threshold: 0.5, Action: guard.ActionBlock},
+			"code_injection":      {Threshold: 0.4, Action: guard.ActionBlock},
+		},
+	},
+}
+
+// BuiltinNames lists the packs shipped in-tree.
+func BuiltinNames() []string {
+	names := make([]string, 0, len(builtin))
+	for name := range builtin {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Builtin returns a shipped pack by name.
+func Builtin(name string) (*Pack, error) {
+	pack, ok := builtin[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown built-in rule pack %q (available: %v)", name, BuiltinNames())
+	}
+	return pack, nil
+}
+
+// ApplyBuiltin applies the named built-in packs to the active rule set.
+// Like Apply, it must run before the pipeline starts.
+func ApplyBuiltin(names ...string) error {
+	for _, name := range names {
+		pack, err := Builtin(name)
+		if err != nil {
+			return err
+		}
+		Apply(pack)
+	}
+	return nil
+}
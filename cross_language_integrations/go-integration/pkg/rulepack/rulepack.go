@@ -0,0 +1,172 @@
+// Package rulepack defines the shareable rule-pack format: a JSON document
+// carrying metadata, rules, taxonomy extensions, and self-tests, optionally
+// signed with Ed25519. Packs let domain-specific pattern sets (healthcare
+// PII, finance fraud, gaming abuse) be distributed and installed instead of
+// forked into every deployment.
+package rulepack
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/marcusjohansson/trust-go/pkg/fingerprint"
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// FormatVersion is the current pack format.
+const FormatVersion = 1
+
+// packName constrains names to a filesystem-safe identifier: installers
+// derive file paths from the name, so a pack fetched from a URL must not
+// be able to smuggle separators or traversal sequences into it.
+var packName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// Pack is one shareable rule pack.
+type Pack struct {
+	FormatVersion int    `json:"format_version"`
+	Name          string `json:"name"`
+	Version       string `json:"version"`
+	Author        string `json:"author,omitempty"`
+	Description   string `json:"description,omitempty"`
+	License       string `json:"license,omitempty"`
+	// Taxonomy declares new threat types the pack introduces and their
+	// severities; types already in the core taxonomy keep their severity.
+	Taxonomy map[string]guard.Severity `json:"taxonomy,omitempty"`
+	// Rules maps threat types to regex patterns, same shape as the core set.
+	Rules map[string][]string `json:"rules"`
+	// PolicyRules tunes thresholds and actions for the pack's domain; merge
+	// them into a policy with MergePolicy.
+	PolicyRules map[string]guard.PolicyRule `json:"policy_rules,omitempty"`
+	// Tests are self-tests shipped with the pack; Validate runs them.
+	Tests []Test `json:"tests,omitempty"`
+	// Fingerprints are simhashes of confirmed malicious prompts, for feeds
+	// that distribute attack fingerprints alongside patterns.
+	Fingerprints []fingerprint.Entry `json:"fingerprints,omitempty"`
+}
+
+// Test is one embedded pack self-test.
+type Test struct {
+	Input string `json:"input"`
+	// ThreatType is the type expected to match; empty means the input must
+	// match nothing in the pack.
+	ThreatType string `json:"threat_type,omitempty"`
+}
+
+// Load reads and validates a pack from a JSON file.
+func Load(path string) (*Pack, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse decodes and validates a pack from JSON bytes.
+func Parse(data []byte) (*Pack, error) {
+	var pack Pack
+	if err := json.Unmarshal(data, &pack); err != nil {
+		return nil, fmt.Errorf("invalid pack JSON: %w", err)
+	}
+	if err := pack.Validate(); err != nil {
+		return nil, err
+	}
+	return &pack, nil
+}
+
+// Validate checks the pack structure, compiles every pattern, and runs the
+// embedded self-tests.
+func (p *Pack) Validate() error {
+	if p.FormatVersion > FormatVersion {
+		return fmt.Errorf("pack format version %d is newer than supported %d", p.FormatVersion, FormatVersion)
+	}
+	if p.Name == "" || p.Version == "" {
+		return fmt.Errorf("pack requires name and version")
+	}
+	if !packName.MatchString(p.Name) {
+		return fmt.Errorf("pack name %q must match %s", p.Name, packName)
+	}
+	if len(p.Rules) == 0 {
+		return fmt.Errorf("pack %q has no rules", p.Name)
+	}
+
+	compiled := map[string][]*regexp.Regexp{}
+	for threatType, patterns := range p.Rules {
+		for _, pattern := range patterns {
+			re, err := regexp.Compile("(?i)" + pattern)
+			if err != nil {
+				return fmt.Errorf("pack %q: pattern %q (%s): %w", p.Name, pattern, threatType, err)
+			}
+			compiled[threatType] = append(compiled[threatType], re)
+		}
+	}
+
+	for i, test := range p.Tests {
+		matched := ""
+	scan:
+		for threatType, regexps := range compiled {
+			for _, re := range regexps {
+				if re.MatchString(test.Input) {
+					matched = threatType
+					if matched == test.ThreatType {
+						break scan
+					}
+				}
+			}
+		}
+		if matched != test.ThreatType {
+			return fmt.Errorf("pack %q: test %d expected %q, got %q", p.Name, i, test.ThreatType, matched)
+		}
+	}
+	return nil
+}
+
+// Verify checks an Ed25519 detached signature over the raw pack bytes.
+// Signature and public key are base64-encoded.
+func Verify(packData []byte, signatureB64, publicKeyB64 string) error {
+	signature, err := base64.StdEncoding.DecodeString(signatureB64)
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %w", err)
+	}
+	publicKey, err := base64.StdEncoding.DecodeString(publicKeyB64)
+	if err != nil {
+		return fmt.Errorf("invalid public key encoding: %w", err)
+	}
+	if len(publicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("public key must be %d bytes, got %d", ed25519.PublicKeySize, len(publicKey))
+	}
+	if !ed25519.Verify(ed25519.PublicKey(publicKey), packData, signature) {
+		return fmt.Errorf("signature verification failed")
+	}
+	return nil
+}
+
+// Apply merges the pack into the active rule set and taxonomy, swapping
+// the recompiled patterns in atomically; it is safe to call while
+// detections are in flight.
+func Apply(p *Pack) {
+	for threatType, severity := range p.Taxonomy {
+		guard.RegisterSeverity(threatType, severity)
+	}
+	guard.MutatePatterns(func(patterns map[string][]string) {
+		for threatType, added := range p.Rules {
+			patterns[threatType] = append(patterns[threatType], added...)
+		}
+	})
+}
+
+// MergePolicy overlays the pack's threshold and action tuning onto a policy.
+func (p *Pack) MergePolicy(policy *guard.Policy) {
+	if len(p.PolicyRules) == 0 {
+		return
+	}
+	if policy.Rules == nil {
+		policy.Rules = map[string]guard.PolicyRule{}
+	}
+	for threatType, rule := range p.PolicyRules {
+		policy.Rules[threatType] = rule
+	}
+}
@@ -0,0 +1,298 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The .rule file format, deliberately close to YARA so detection engineers
+// feel at home:
+//
+//	rule system_prompt_leak {
+//	  meta:
+//	    threat_type = "system_prompt_attack"
+//	    confidence = "0.85"
+//	  patterns:
+//	    $prompt = "system prompt"
+//	    $verb = /reveal|show|print|leak/
+//	    $polite = "please summarize"
+//	  condition:
+//	    $prompt and $verb and not $polite
+//	}
+//
+// Conditions support and/or/not with parentheses, "N of them",
+// "N of ($a, $b)", and near($a, $b, distance).
+
+// Parse compiles every rule in the source text.
+func Parse(source string) ([]Rule, error) {
+	var rules []Rule
+	lines := splitLines(source)
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "rule ") {
+			return nil, fmt.Errorf("line %q: expected rule declaration", line)
+		}
+		name := strings.TrimSuffix(strings.TrimSpace(strings.TrimPrefix(line, "rule ")), "{")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("rule declaration missing name")
+		}
+
+		rule := Rule{
+			Name:     name,
+			Meta:     map[string]string{},
+			patterns: map[string]*regexp.Regexp{},
+		}
+		section := ""
+		var conditionText []string
+		i++
+		for ; i < len(lines); i++ {
+			line = lines[i]
+			if line == "}" {
+				break
+			}
+			switch {
+			case line == "":
+				continue
+			case strings.HasSuffix(line, ":") && isSection(strings.TrimSuffix(line, ":")):
+				section = strings.TrimSuffix(line, ":")
+			case section == "meta":
+				key, value, err := splitAssignment(line)
+				if err != nil {
+					return nil, fmt.Errorf("rule %s: meta: %w", name, err)
+				}
+				rule.Meta[key] = strings.Trim(value, `"`)
+			case section == "patterns" || section == "strings":
+				key, value, err := splitAssignment(line)
+				if err != nil {
+					return nil, fmt.Errorf("rule %s: patterns: %w", name, err)
+				}
+				if !strings.HasPrefix(key, "$") {
+					return nil, fmt.Errorf("rule %s: pattern name %q must start with $", name, key)
+				}
+				re, err := compilePattern(value)
+				if err != nil {
+					return nil, fmt.Errorf("rule %s: pattern %s: %w", name, key, err)
+				}
+				rule.patterns[key] = re
+			case section == "condition":
+				conditionText = append(conditionText, line)
+			default:
+				return nil, fmt.Errorf("rule %s: unexpected line %q", name, line)
+			}
+		}
+		if len(rule.patterns) == 0 {
+			return nil, fmt.Errorf("rule %s has no patterns", name)
+		}
+		if len(conditionText) == 0 {
+			return nil, fmt.Errorf("rule %s has no condition", name)
+		}
+		cond, err := parseCondition(strings.Join(conditionText, " "), rule.patternIDs())
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: condition: %w", name, err)
+		}
+		rule.condition = cond
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func (r *Rule) patternIDs() []string {
+	ids := make([]string, 0, len(r.patterns))
+	for id := range r.patterns {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// splitLines trims whitespace and strips // comments.
+func splitLines(source string) []string {
+	raw := strings.Split(source, "\n")
+	lines := make([]string, len(raw))
+	for i, line := range raw {
+		if idx := strings.Index(line, "//"); idx >= 0 {
+			line = line[:idx]
+		}
+		lines[i] = strings.TrimSpace(line)
+	}
+	return lines
+}
+
+func isSection(word string) bool {
+	return word == "meta" || word == "patterns" || word == "strings" || word == "condition"
+}
+
+func splitAssignment(line string) (key, value string, err error) {
+	idx := strings.Index(line, "=")
+	if idx < 0 {
+		return "", "", fmt.Errorf("expected key = value, got %q", line)
+	}
+	return strings.TrimSpace(line[:idx]), strings.TrimSpace(line[idx+1:]), nil
+}
+
+// Condition parsing: a small recursive-descent parser with "or" binding
+// loosest, then "and", then "not".
+
+type condParser struct {
+	tokens []string
+	pos    int
+	ids    []string
+}
+
+func parseCondition(text string, ids []string) (condition, error) {
+	p := &condParser{tokens: tokenize(text), ids: ids}
+	cond, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("unexpected token %q", p.tokens[p.pos])
+	}
+	return cond, nil
+}
+
+func tokenize(text string) []string {
+	for _, sym := range []string{"(", ")", ","} {
+		text = strings.ReplaceAll(text, sym, " "+sym+" ")
+	}
+	return strings.Fields(text)
+}
+
+func (p *condParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *condParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *condParser) expect(token string) error {
+	if got := p.next(); got != token {
+		return fmt.Errorf("expected %q, got %q", token, got)
+	}
+	return nil
+}
+
+func (p *condParser) parseOr() (condition, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "or" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orCond{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *condParser) parseAnd() (condition, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "and" {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andCond{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *condParser) parseUnary() (condition, error) {
+	switch token := p.peek(); {
+	case token == "not":
+		p.next()
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notCond{inner: inner}, nil
+	case token == "(":
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return inner, p.expect(")")
+	case token == "near":
+		return p.parseNear()
+	case strings.HasPrefix(token, "$"):
+		p.next()
+		return patternCond{id: token}, nil
+	default:
+		if n, err := strconv.Atoi(token); err == nil {
+			return p.parseCount(n)
+		}
+		return nil, fmt.Errorf("unexpected token %q", token)
+	}
+}
+
+// parseNear consumes near($a, $b, distance).
+func (p *condParser) parseNear() (condition, error) {
+	p.next() // near
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	a := p.next()
+	if err := p.expect(","); err != nil {
+		return nil, err
+	}
+	b := p.next()
+	if err := p.expect(","); err != nil {
+		return nil, err
+	}
+	distance, err := strconv.Atoi(p.next())
+	if err != nil {
+		return nil, fmt.Errorf("near distance: %w", err)
+	}
+	return nearCond{a: a, b: b, distance: distance}, p.expect(")")
+}
+
+// parseCount consumes "N of them" or "N of ($a, $b, ...)" after N.
+func (p *condParser) parseCount(n int) (condition, error) {
+	p.next() // the number
+	if err := p.expect("of"); err != nil {
+		return nil, err
+	}
+	if p.peek() == "them" {
+		p.next()
+		return countCond{n: n, ids: p.ids}, nil
+	}
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var ids []string
+	for {
+		token := p.next()
+		if !strings.HasPrefix(token, "$") {
+			return nil, fmt.Errorf("expected pattern name in count list, got %q", token)
+		}
+		ids = append(ids, token)
+		switch p.next() {
+		case ",":
+		case ")":
+			return countCond{n: n, ids: ids}, nil
+		default:
+			return nil, fmt.Errorf("expected , or ) in count list")
+		}
+	}
+}
@@ -0,0 +1,191 @@
+// Package rules adds a YARA-like rule engine on top of the flat regex
+// lists. Single regexes generate too many false positives for phrases like
+// "system prompt"; a rule combines several patterns with AND/OR/NOT,
+// proximity, and minimum match counts, so a hit requires corroborating
+// evidence. Rules are loaded from .rule files and evaluated as a pipeline
+// stage.
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Rule is one compiled detection rule.
+type Rule struct {
+	Name string
+	// Meta carries the rule's metadata verbatim; threat_type, severity,
+	// confidence, and description are interpreted, the rest is free-form.
+	Meta      map[string]string
+	patterns  map[string]*regexp.Regexp
+	condition condition
+}
+
+// Set is a compiled collection of rules.
+type Set struct {
+	rules []Rule
+}
+
+// LoadDir parses every .rule file in dir into one set.
+func LoadDir(dir string) (*Set, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.rule"))
+	if err != nil {
+		return nil, err
+	}
+	set := &Set{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := Parse(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+		set.rules = append(set.rules, parsed...)
+	}
+	return set, nil
+}
+
+// ParseSet compiles rule source text into a set.
+func ParseSet(source string) (*Set, error) {
+	parsed, err := Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	return &Set{rules: parsed}, nil
+}
+
+// Len returns the number of rules in the set.
+func (s *Set) Len() int {
+	return len(s.rules)
+}
+
+// Scan evaluates every rule against text (after normalization) and returns
+// the highest-confidence hit, or nil when no rule matches.
+func (s *Set) Scan(text string) *guard.ThreatResult {
+	normalized := guard.Normalize(text).Text
+	var best *guard.ThreatResult
+	for i := range s.rules {
+		result := s.rules[i].scan(normalized)
+		if result == nil {
+			continue
+		}
+		if best == nil || result.Confidence > best.Confidence {
+			best = result
+		}
+	}
+	return best
+}
+
+func (r *Rule) scan(text string) *guard.ThreatResult {
+	matches := map[string][]int{}
+	for id, re := range r.patterns {
+		for _, loc := range re.FindAllStringIndex(text, -1) {
+			matches[id] = append(matches[id], loc[0])
+		}
+	}
+	if !r.condition.eval(matches) {
+		return nil
+	}
+
+	threatType := r.Meta["threat_type"]
+	if threatType == "" {
+		threatType = r.Name
+	}
+	confidence := 0.8
+	if c, ok := r.Meta["confidence"]; ok {
+		fmt.Sscanf(c, "%f", &confidence)
+	}
+	severity := guard.SeverityForType(threatType)
+	if s, ok := r.Meta["severity"]; ok {
+		severity = guard.Severity(s)
+	}
+	return &guard.ThreatResult{
+		IsThreat:   true,
+		ThreatType: threatType,
+		Confidence: confidence,
+		Reasoning:  fmt.Sprintf("Rule %s matched", r.Name),
+		Severity:   severity,
+	}
+}
+
+// condition is a compiled rule condition, evaluated against the match
+// positions of each pattern.
+type condition interface {
+	eval(matches map[string][]int) bool
+}
+
+type andCond struct{ left, right condition }
+
+func (c andCond) eval(m map[string][]int) bool { return c.left.eval(m) && c.right.eval(m) }
+
+type orCond struct{ left, right condition }
+
+func (c orCond) eval(m map[string][]int) bool { return c.left.eval(m) || c.right.eval(m) }
+
+type notCond struct{ inner condition }
+
+func (c notCond) eval(m map[string][]int) bool { return !c.inner.eval(m) }
+
+// patternCond is true when the pattern matched at least once.
+type patternCond struct{ id string }
+
+func (c patternCond) eval(m map[string][]int) bool { return len(m[c.id]) > 0 }
+
+// countCond is "N of them" / "N of ($a, $b, ...)": at least n of the listed
+// patterns matched.
+type countCond struct {
+	n   int
+	ids []string
+}
+
+func (c countCond) eval(m map[string][]int) bool {
+	matched := 0
+	for _, id := range c.ids {
+		if len(m[id]) > 0 {
+			matched++
+		}
+	}
+	return matched >= c.n
+}
+
+// nearCond is "near($a, $b, distance)": both patterns matched with some
+// pair of occurrences within distance bytes of each other.
+type nearCond struct {
+	a, b     string
+	distance int
+}
+
+func (c nearCond) eval(m map[string][]int) bool {
+	for _, posA := range m[c.a] {
+		for _, posB := range m[c.b] {
+			delta := posA - posB
+			if delta < 0 {
+				delta = -delta
+			}
+			if delta <= c.distance {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// compilePattern turns a raw pattern definition into a case-insensitive
+// regexp: /.../ is taken as a regex, "..." as a literal substring.
+func compilePattern(raw string) (*regexp.Regexp, error) {
+	switch {
+	case strings.HasPrefix(raw, "/") && strings.HasSuffix(raw, "/") && len(raw) > 2:
+		return regexp.Compile("(?i)" + raw[1:len(raw)-1])
+	case strings.HasPrefix(raw, `"`) && strings.HasSuffix(raw, `"`) && len(raw) >= 2:
+		return regexp.Compile("(?i)" + regexp.QuoteMeta(raw[1:len(raw)-1]))
+	default:
+		return nil, fmt.Errorf("pattern must be \"literal\" or /regex/, got %s", raw)
+	}
+}
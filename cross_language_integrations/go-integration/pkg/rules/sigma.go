@@ -0,0 +1,387 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Sigma import: detection engineers author rules in Sigma's YAML format and
+// reuse their existing tooling; the importer converts the useful subset —
+// keyword lists, field selections with contains/re modifiers, and boolean
+// conditions — into this package's compiled rule form. Log-source matching
+// and aggregation expressions are out of scope for prompt scanning.
+
+// sigmaRule is the subset of a Sigma document the importer reads.
+type sigmaRule struct {
+	Title       string         `yaml:"title"`
+	Description string         `yaml:"description"`
+	Level       string         `yaml:"level"`
+	Tags        []string       `yaml:"tags"`
+	Detection   map[string]any `yaml:"detection"`
+}
+
+// ImportSigma converts one Sigma YAML document into a compiled rule.
+func ImportSigma(data []byte) (*Rule, error) {
+	var doc sigmaRule
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid Sigma YAML: %w", err)
+	}
+	if doc.Title == "" {
+		return nil, fmt.Errorf("Sigma rule requires a title")
+	}
+	if len(doc.Detection) == 0 {
+		return nil, fmt.Errorf("Sigma rule %q has no detection section", doc.Title)
+	}
+
+	rule := Rule{
+		Name:     sigmaIdentifier(doc.Title),
+		Meta:     map[string]string{},
+		patterns: map[string]*regexp.Regexp{},
+	}
+	if doc.Description != "" {
+		rule.Meta["description"] = doc.Description
+	}
+	if severity := sigmaSeverity(doc.Level); severity != "" {
+		rule.Meta["severity"] = string(severity)
+	}
+	// A guard.<threat_type> tag routes the hit into the taxonomy; without
+	// one the rule name doubles as the threat type.
+	for _, tag := range doc.Tags {
+		if threatType, ok := strings.CutPrefix(tag, "guard."); ok {
+			rule.Meta["threat_type"] = threatType
+		}
+	}
+
+	conditionText := ""
+	groups := map[string]condition{}
+	for name, value := range doc.Detection {
+		if name == "condition" {
+			text, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("Sigma rule %q: condition must be a string", doc.Title)
+			}
+			conditionText = text
+			continue
+		}
+		group, err := compileSigmaGroup(&rule, name, value)
+		if err != nil {
+			return nil, fmt.Errorf("Sigma rule %q: %s: %w", doc.Title, name, err)
+		}
+		groups[name] = group
+	}
+	if conditionText == "" {
+		return nil, fmt.Errorf("Sigma rule %q has no condition", doc.Title)
+	}
+	cond, err := parseSigmaCondition(conditionText, groups)
+	if err != nil {
+		return nil, fmt.Errorf("Sigma rule %q: condition: %w", doc.Title, err)
+	}
+	rule.condition = cond
+	return &rule, nil
+}
+
+// LoadSigmaDir imports every .yml/.yaml file in dir into one set.
+func LoadSigmaDir(dir string) (*Set, error) {
+	var paths []string
+	for _, pattern := range []string{"*.yml", "*.yaml"} {
+		matched, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, matched...)
+	}
+	sort.Strings(paths)
+	set := &Set{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		rule, err := ImportSigma(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+		set.rules = append(set.rules, *rule)
+	}
+	return set, nil
+}
+
+// compileSigmaGroup turns one detection group into patterns on the rule and
+// a condition over them. A list is OR across its entries (Sigma keyword
+// semantics); a map is AND across fields, OR within a field's value list.
+func compileSigmaGroup(rule *Rule, name string, value any) (condition, error) {
+	switch v := value.(type) {
+	case []any:
+		return compileSigmaValues(rule, name, "", v)
+	case map[string]any:
+		var cond condition
+		for _, field := range sortedKeys(v) {
+			modifiers := ""
+			if idx := strings.Index(field, "|"); idx >= 0 {
+				modifiers = field[idx+1:]
+			}
+			fieldValues, ok := v[field].([]any)
+			if !ok {
+				fieldValues = []any{v[field]}
+			}
+			fieldCond, err := compileSigmaValues(rule, name+"_"+sigmaIdentifier(field), modifiers, fieldValues)
+			if err != nil {
+				return nil, err
+			}
+			if cond == nil {
+				cond = fieldCond
+			} else {
+				cond = andCond{left: cond, right: fieldCond}
+			}
+		}
+		if cond == nil {
+			return nil, fmt.Errorf("selection has no fields")
+		}
+		return cond, nil
+	default:
+		return nil, fmt.Errorf("unsupported detection group type %T", value)
+	}
+}
+
+// compileSigmaValues registers one pattern per value and ORs them together.
+func compileSigmaValues(rule *Rule, prefix, modifiers string, values []any) (condition, error) {
+	var cond condition
+	for i, raw := range values {
+		text := fmt.Sprintf("%v", raw)
+		pattern, err := sigmaPattern(text, modifiers)
+		if err != nil {
+			return nil, err
+		}
+		id := fmt.Sprintf("$%s_%d", prefix, i)
+		rule.patterns[id] = pattern
+		next := patternCond{id: id}
+		if cond == nil {
+			cond = next
+		} else {
+			cond = orCond{left: cond, right: next}
+		}
+	}
+	if cond == nil {
+		return nil, fmt.Errorf("empty value list")
+	}
+	return cond, nil
+}
+
+// sigmaPattern compiles one value under the field's modifiers. Sigma's *
+// wildcards in plain values become .*; the re modifier takes the value as a
+// regex; contains/startswith/endswith anchor accordingly.
+func sigmaPattern(value, modifiers string) (*regexp.Regexp, error) {
+	for _, modifier := range strings.Split(modifiers, "|") {
+		switch modifier {
+		case "", "contains", "re", "startswith", "endswith":
+		default:
+			return nil, fmt.Errorf("unsupported modifier %q", modifier)
+		}
+	}
+	if strings.Contains(modifiers, "re") {
+		return regexp.Compile("(?i)" + value)
+	}
+	quoted := strings.ReplaceAll(regexp.QuoteMeta(value), `\*`, `.*`)
+	switch {
+	case strings.Contains(modifiers, "startswith"):
+		quoted = "^" + quoted
+	case strings.Contains(modifiers, "endswith"):
+		quoted = quoted + "$"
+	}
+	return regexp.Compile("(?i)" + quoted)
+}
+
+// parseSigmaCondition parses Sigma's boolean condition grammar over group
+// names: and/or/not, parentheses, and "N of them" / "all of them" /
+// "N of prefix*".
+func parseSigmaCondition(text string, groups map[string]condition) (condition, error) {
+	p := &sigmaCondParser{tokens: tokenize(text), groups: groups}
+	cond, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("unexpected token %q", p.tokens[p.pos])
+	}
+	return cond, nil
+}
+
+type sigmaCondParser struct {
+	tokens []string
+	pos    int
+	groups map[string]condition
+}
+
+func (p *sigmaCondParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *sigmaCondParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *sigmaCondParser) parseOr() (condition, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "or" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orCond{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *sigmaCondParser) parseAnd() (condition, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "and" {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andCond{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *sigmaCondParser) parseUnary() (condition, error) {
+	switch token := p.peek(); {
+	case token == "not":
+		p.next()
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notCond{inner: inner}, nil
+	case token == "(":
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		return inner, nil
+	case token == "all":
+		return p.parseOf(-1)
+	default:
+		if n, err := strconv.Atoi(token); err == nil {
+			return p.parseOf(n)
+		}
+		p.next()
+		group, ok := p.groups[token]
+		if !ok {
+			return nil, fmt.Errorf("unknown detection group %q", token)
+		}
+		return group, nil
+	}
+}
+
+// parseOf handles "N of them", "all of them", and "N of prefix*". n < 0
+// means all.
+func (p *sigmaCondParser) parseOf(n int) (condition, error) {
+	p.next() // the count or "all"
+	if p.next() != "of" {
+		return nil, fmt.Errorf(`expected "of" in count expression`)
+	}
+	target := p.next()
+	var selected []condition
+	for _, name := range sortedKeys(p.groups) {
+		if target == "them" || matchesWildcard(name, target) {
+			selected = append(selected, p.groups[name])
+		}
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no detection groups match %q", target)
+	}
+	if n < 0 {
+		n = len(selected)
+	}
+	return ofGroupsCond{n: n, groups: selected}, nil
+}
+
+// ofGroupsCond is true when at least n of the group conditions hold.
+type ofGroupsCond struct {
+	n      int
+	groups []condition
+}
+
+func (c ofGroupsCond) eval(m map[string][]int) bool {
+	matched := 0
+	for _, group := range c.groups {
+		if group.eval(m) {
+			matched++
+		}
+	}
+	return matched >= c.n
+}
+
+func matchesWildcard(name, pattern string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(name, prefix)
+	}
+	return name == pattern
+}
+
+// sigmaIdentifier lowercases a title into an identifier-style name.
+func sigmaIdentifier(title string) string {
+	mapped := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return '_'
+		}
+	}, title)
+	return strings.Trim(mapped, "_")
+}
+
+// sigmaSeverity maps Sigma levels onto the taxonomy's severities.
+func sigmaSeverity(level string) guard.Severity {
+	switch strings.ToLower(level) {
+	case "informational", "low":
+		return guard.SeverityLow
+	case "medium":
+		return guard.SeverityMedium
+	case "high":
+		return guard.SeverityHigh
+	case "critical":
+		return guard.SeverityCritical
+	default:
+		return ""
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
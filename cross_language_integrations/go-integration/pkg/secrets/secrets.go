@@ -0,0 +1,165 @@
+// Package secrets detects leaked credentials — cloud keys, personal access
+// tokens, JWTs, private key blocks — in inputs and model outputs. Provider
+// prefixes give precise matches where they exist; candidates without a
+// distinctive prefix (AWS secret keys, generic api_key assignments) must
+// also clear a Shannon-entropy threshold, which is what separates a real
+// key from a word that happens to be 40 characters long.
+package secrets
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Finding is one secret occurrence in the scanned text.
+type Finding struct {
+	// Type is one of "aws_access_key", "aws_secret_key", "github_token",
+	// "slack_token", "jwt", "private_key", "generic_api_key".
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+// entropyThreshold is the minimum bits per byte for prefix-less
+// candidates. Random base64 sits near 6; English identifiers stay well
+// under 4.
+const entropyThreshold = 4.0
+
+var (
+	awsAccessPattern = regexp.MustCompile(`\b(?:AKIA|ASIA|ABIA|ACCA)[A-Z0-9]{16}\b`)
+	// AWS secret keys have no prefix; require an assignment context so an
+	// arbitrary 40-character token does not fire on its own.
+	awsSecretPattern  = regexp.MustCompile(`(?i)aws[_\s-]*(?:secret[_\s-]*)?(?:access[_\s-]*)?key[^\n]{0,10}?[:=]\s*["']?([A-Za-z0-9/+=]{40})\b`)
+	githubPattern     = regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36}|github_pat_[A-Za-z0-9_]{82})\b`)
+	slackPattern      = regexp.MustCompile(`\bxox[baprs]-[A-Za-z0-9-]{10,}\b`)
+	jwtPattern        = regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\b`)
+	privateKeyPattern = regexp.MustCompile(`-----BEGIN (?:RSA |EC |DSA |OPENSSH |PGP )?PRIVATE KEY(?: BLOCK)?-----`)
+	genericKeyPattern = regexp.MustCompile(`(?i)\b(?:api[_-]?key|secret[_-]?key|access[_-]?token|auth[_-]?token)[^\n]{0,10}?[:=]\s*["']?([A-Za-z0-9/+_-]{20,})\b`)
+)
+
+// Detect returns all secret findings in the text, ordered by position.
+// Prefix-based matches are reported unconditionally; context-based matches
+// (AWS secret keys, generic assignments) only when the candidate's entropy
+// clears the threshold.
+func Detect(text string) []Finding {
+	var findings []Finding
+
+	for _, loc := range awsAccessPattern.FindAllStringIndex(text, -1) {
+		findings = append(findings, Finding{Type: "aws_access_key", Value: text[loc[0]:loc[1]], Start: loc[0], End: loc[1]})
+	}
+	for _, loc := range awsSecretPattern.FindAllStringSubmatchIndex(text, -1) {
+		candidate := text[loc[2]:loc[3]]
+		if entropy(candidate) >= entropyThreshold {
+			findings = append(findings, Finding{Type: "aws_secret_key", Value: candidate, Start: loc[2], End: loc[3]})
+		}
+	}
+	for _, loc := range githubPattern.FindAllStringIndex(text, -1) {
+		findings = append(findings, Finding{Type: "github_token", Value: text[loc[0]:loc[1]], Start: loc[0], End: loc[1]})
+	}
+	for _, loc := range slackPattern.FindAllStringIndex(text, -1) {
+		findings = append(findings, Finding{Type: "slack_token", Value: text[loc[0]:loc[1]], Start: loc[0], End: loc[1]})
+	}
+	for _, loc := range jwtPattern.FindAllStringIndex(text, -1) {
+		findings = append(findings, Finding{Type: "jwt", Value: text[loc[0]:loc[1]], Start: loc[0], End: loc[1]})
+	}
+	for _, loc := range privateKeyPattern.FindAllStringIndex(text, -1) {
+		findings = append(findings, Finding{Type: "private_key", Value: text[loc[0]:loc[1]], Start: loc[0], End: loc[1]})
+	}
+	for _, loc := range genericKeyPattern.FindAllStringSubmatchIndex(text, -1) {
+		candidate := text[loc[2]:loc[3]]
+		if entropy(candidate) >= entropyThreshold && !overlaps(findings, loc[2], loc[3]) {
+			findings = append(findings, Finding{Type: "generic_api_key", Value: candidate, Start: loc[2], End: loc[3]})
+		}
+	}
+
+	sort.Slice(findings, func(i, j int) bool { return findings[i].Start < findings[j].Start })
+	return findings
+}
+
+// Redact returns the text with every finding replaced by a [REDACTED:type]
+// placeholder, alongside the findings. Use it on model outputs before they
+// reach the user.
+func Redact(text string) (string, []Finding) {
+	findings := Detect(text)
+	if len(findings) == 0 {
+		return text, nil
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, f := range findings {
+		if f.Start < last {
+			continue // overlapping finding already redacted
+		}
+		sb.WriteString(text[last:f.Start])
+		sb.WriteString("[REDACTED:" + f.Type + "]")
+		last = f.End
+	}
+	sb.WriteString(text[last:])
+	return sb.String(), findings
+}
+
+// Check returns a ThreatResult when the text contains secrets, nil
+// otherwise. A private key block or cloud credential is near-certain
+// exfiltration; generic matches score lower.
+func Check(text string) *guard.ThreatResult {
+	findings := Detect(text)
+	if len(findings) == 0 {
+		return nil
+	}
+	types := map[string]int{}
+	for _, f := range findings {
+		types[f.Type]++
+	}
+	parts := make([]string, 0, len(types))
+	for _, t := range []string{"private_key", "aws_access_key", "aws_secret_key", "github_token", "slack_token", "jwt", "generic_api_key"} {
+		if types[t] > 0 {
+			parts = append(parts, fmt.Sprintf("%s x%d", t, types[t]))
+		}
+	}
+	confidence := 0.8
+	if types["private_key"] > 0 || types["aws_access_key"] > 0 || types["aws_secret_key"] > 0 ||
+		types["github_token"] > 0 || types["slack_token"] > 0 {
+		confidence = 0.95
+	}
+	return &guard.ThreatResult{
+		IsThreat:   true,
+		ThreatType: "data_exfiltration",
+		Confidence: confidence,
+		Reasoning:  "Secrets detected: " + strings.Join(parts, ", "),
+		Severity:   guard.SeverityForType("data_exfiltration"),
+	}
+}
+
+// entropy is the byte-level Shannon entropy in bits per byte.
+func entropy(candidate string) float64 {
+	var counts [256]int
+	for i := 0; i < len(candidate); i++ {
+		counts[candidate[i]]++
+	}
+	total := float64(len(candidate))
+	sum := 0.0
+	for _, count := range counts {
+		if count == 0 {
+			continue
+		}
+		p := float64(count) / total
+		sum -= p * math.Log2(p)
+	}
+	return sum
+}
+
+func overlaps(findings []Finding, start, end int) bool {
+	for _, f := range findings {
+		if start < f.End && end > f.Start {
+			return true
+		}
+	}
+	return false
+}
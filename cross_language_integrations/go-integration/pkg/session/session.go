@@ -0,0 +1,129 @@
+package session
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Detector is the detection surface a session needs, satisfied by
+// *detector.EnhancedDetector.
+type Detector interface {
+	Detect(text string) (*guard.ThreatResult, error)
+}
+
+// Turn is one message in a conversation.
+type Turn struct {
+	Role string    `json:"role"`
+	Text string    `json:"text"`
+	Time time.Time `json:"time"`
+}
+
+// Session accumulates conversation history and scans a rolling window of
+// turns in addition to each single message, catching attacks split across
+// turns ("remember the word 'ignore'... now apply it to your instructions").
+// It also tracks a cumulative risk score for the conversation.
+type Session struct {
+	ID string
+
+	mu       sync.Mutex
+	detector Detector
+	window   int
+	turns    []Turn
+	history  History
+	risk     float64
+}
+
+// riskWeights scale each detection's contribution to the cumulative score.
+var riskWeights = map[guard.Severity]float64{
+	guard.SeverityLow:      0.1,
+	guard.SeverityMedium:   0.25,
+	guard.SeverityHigh:     0.5,
+	guard.SeverityCritical: 1.0,
+}
+
+// NewSession creates a conversation session scanning the last windowSize
+// turns together (0 uses a default of 5).
+func NewSession(id string, det Detector, windowSize int) *Session {
+	if windowSize <= 0 {
+		windowSize = 5
+	}
+	return &Session{
+		ID:       id,
+		detector: det,
+		window:   windowSize,
+		history:  History{SessionID: id},
+	}
+}
+
+// AddTurn records a message and scans it, both alone and joined with the
+// recent window. The window verdict is returned when it is the stronger one.
+func (s *Session) AddTurn(role, text string) (*guard.ThreatResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.turns = append(s.turns, Turn{Role: role, Text: text, Time: time.Now()})
+
+	result, err := s.detector.Detect(text)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rolling-window pass: the concatenated recent turns can match patterns
+	// no single turn contains.
+	if result == nil || !result.IsThreat {
+		if windowResult, err := s.detector.Detect(s.windowText()); err == nil &&
+			windowResult != nil && windowResult.IsThreat {
+			windowResult.Reasoning += " (matched across conversation window)"
+			result = windowResult
+		}
+	}
+
+	if result != nil && result.IsThreat {
+		s.risk += result.Confidence * riskWeights[result.Severity]
+	}
+	s.history.Add(Event{Input: text, Result: result, Action: actionFor(result)})
+	return result, nil
+}
+
+// windowText joins the text of the last window turns.
+func (s *Session) windowText() string {
+	start := len(s.turns) - s.window
+	if start < 0 {
+		start = 0
+	}
+	parts := make([]string, 0, len(s.turns)-start)
+	for _, turn := range s.turns[start:] {
+		parts = append(parts, turn.Text)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// RiskScore returns the cumulative risk accumulated over the session.
+func (s *Session) RiskScore() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.risk
+}
+
+// Turns returns a copy of the conversation so far.
+func (s *Session) Turns() []Turn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	turns := make([]Turn, len(s.turns))
+	copy(turns, s.turns)
+	return turns
+}
+
+// Summary produces the security-event summary for this session.
+func (s *Session) Summary() *Summary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return Summarize(&s.history)
+}
+
+func actionFor(result *guard.ThreatResult) guard.Action {
+	return guard.DefaultPolicy().Evaluate(result)
+}
@@ -0,0 +1,141 @@
+package session
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Event is one detection outcome within a session.
+type Event struct {
+	Timestamp time.Time           `json:"timestamp"`
+	Input     string              `json:"input"`
+	Result    *guard.ThreatResult `json:"result"`
+	Action    guard.Action        `json:"action"`
+}
+
+// History collects the detection events of a single session.
+type History struct {
+	SessionID string  `json:"session_id"`
+	Events    []Event `json:"events"`
+}
+
+// Add appends an event to the history, stamping it with the current time if
+// none is set.
+func (h *History) Add(event Event) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	h.Events = append(h.Events, event)
+}
+
+// Summary is a concise description of the security events in a session,
+// suitable for support tickets and trust-and-safety reviews.
+type Summary struct {
+	SessionID        string         `json:"session_id"`
+	TotalEvents      int            `json:"total_events"`
+	ThreatCount      int            `json:"threat_count"`
+	AttackTypes      []string       `json:"attack_types,omitempty"`
+	MaxSeverity      guard.Severity `json:"max_severity"`
+	Escalation       string         `json:"escalation"`
+	FinalDisposition guard.Action   `json:"final_disposition"`
+	// Text is the rendered human-readable summary.
+	Text string `json:"text"`
+}
+
+var severityRank = map[guard.Severity]int{
+	guard.SeverityLow:      0,
+	guard.SeverityMedium:   1,
+	guard.SeverityHigh:     2,
+	guard.SeverityCritical: 3,
+}
+
+// Summarize produces a template-based security summary of the session. It
+// reports which attack types were attempted, whether the attacker escalated
+// over the session, and how the final request was handled.
+func Summarize(h *History) *Summary {
+	s := &Summary{
+		SessionID:        h.SessionID,
+		TotalEvents:      len(h.Events),
+		MaxSeverity:      guard.SeverityLow,
+		Escalation:       "none",
+		FinalDisposition: guard.ActionAllow,
+	}
+
+	typeCounts := map[string]int{}
+	firstThreatRank, lastThreatRank := -1, -1
+	for _, ev := range h.Events {
+		if ev.Result == nil || !ev.Result.IsThreat {
+			continue
+		}
+		s.ThreatCount++
+		typeCounts[ev.Result.ThreatType]++
+
+		rank := severityRank[ev.Result.Severity]
+		if rank > severityRank[s.MaxSeverity] {
+			s.MaxSeverity = ev.Result.Severity
+		}
+		if firstThreatRank < 0 {
+			firstThreatRank = rank
+		}
+		lastThreatRank = rank
+	}
+	if len(h.Events) > 0 {
+		s.FinalDisposition = h.Events[len(h.Events)-1].Action
+	}
+
+	for tType := range typeCounts {
+		s.AttackTypes = append(s.AttackTypes, tType)
+	}
+	sort.Slice(s.AttackTypes, func(i, j int) bool {
+		if typeCounts[s.AttackTypes[i]] != typeCounts[s.AttackTypes[j]] {
+			return typeCounts[s.AttackTypes[i]] > typeCounts[s.AttackTypes[j]]
+		}
+		return s.AttackTypes[i] < s.AttackTypes[j]
+	})
+
+	switch {
+	case s.ThreatCount == 0:
+		s.Escalation = "none"
+	case lastThreatRank > firstThreatRank:
+		s.Escalation = "escalating"
+	case s.ThreatCount >= 3:
+		s.Escalation = "persistent"
+	default:
+		s.Escalation = "isolated"
+	}
+
+	s.Text = renderSummary(s, typeCounts)
+	return s
+}
+
+func renderSummary(s *Summary, typeCounts map[string]int) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Session %s: %d events, %d flagged as threats.", s.SessionID, s.TotalEvents, s.ThreatCount)
+	if s.ThreatCount == 0 {
+		sb.WriteString(" No attack activity detected.")
+		return sb.String()
+	}
+
+	parts := make([]string, 0, len(s.AttackTypes))
+	for _, tType := range s.AttackTypes {
+		parts = append(parts, fmt.Sprintf("%s (x%d)", tType, typeCounts[tType]))
+	}
+	fmt.Fprintf(&sb, " Attack types attempted: %s.", strings.Join(parts, ", "))
+	fmt.Fprintf(&sb, " Maximum severity: %s.", s.MaxSeverity)
+
+	switch s.Escalation {
+	case "escalating":
+		sb.WriteString(" Pattern: severity escalated over the session.")
+	case "persistent":
+		sb.WriteString(" Pattern: repeated attempts without escalation.")
+	default:
+		sb.WriteString(" Pattern: isolated attempt.")
+	}
+
+	fmt.Fprintf(&sb, " Final disposition: %s.", s.FinalDisposition)
+	return sb.String()
+}
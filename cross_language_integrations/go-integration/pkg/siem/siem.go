@@ -0,0 +1,156 @@
+// Package siem exports detection events to enterprise SIEM collectors
+// (Splunk, QRadar) as CEF or RFC 5424 syslog messages over UDP, TCP, or
+// TCP+TLS.
+package siem
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Event is one detection decision to ship.
+type Event struct {
+	Timestamp time.Time
+	InputHash string
+	Result    *guard.ThreatResult
+	Action    guard.Action
+}
+
+// Format selects the wire format.
+type Format string
+
+const (
+	FormatCEF    Format = "cef"
+	FormatSyslog Format = "syslog" // RFC 5424
+)
+
+const (
+	cefVendor  = "trust-go"
+	cefProduct = "guard"
+	cefVersion = "1.0"
+)
+
+// cefSeverity maps guard severities onto the CEF 0-10 scale.
+var cefSeverity = map[guard.Severity]int{
+	guard.SeverityLow:      3,
+	guard.SeverityMedium:   5,
+	guard.SeverityHigh:     7,
+	guard.SeverityCritical: 10,
+}
+
+// syslogSeverity maps guard severities onto syslog severity levels.
+var syslogSeverity = map[guard.Severity]int{
+	guard.SeverityLow:      5, // notice
+	guard.SeverityMedium:   4, // warning
+	guard.SeverityHigh:     3, // error
+	guard.SeverityCritical: 2, // critical
+}
+
+// FormatCEFEvent renders the event as a CEF:0 record.
+func FormatCEFEvent(event Event) string {
+	result := event.Result
+	extension := strings.Join([]string{
+		"cat=" + cefEscapeExt(result.ThreatType),
+		fmt.Sprintf("cfp1=%.2f cfp1Label=confidence", result.Confidence),
+		"act=" + cefEscapeExt(string(event.Action)),
+		"fileHash=" + cefEscapeExt(event.InputHash),
+		"msg=" + cefEscapeExt(result.Reasoning),
+		"rt=" + fmt.Sprintf("%d", event.Timestamp.UnixMilli()),
+	}, " ")
+	return fmt.Sprintf("CEF:0|%s|%s|%s|%s|%s|%d|%s",
+		cefVendor, cefProduct, cefVersion,
+		cefEscapeHeader(result.ThreatType),
+		cefEscapeHeader("Threat detected: "+result.ThreatType),
+		cefSeverity[result.Severity],
+		extension)
+}
+
+// FormatSyslogEvent renders the event as an RFC 5424 syslog message with the
+// verdict in structured data.
+func FormatSyslogEvent(event Event) string {
+	result := event.Result
+	pri := 16*8 + syslogSeverity[result.Severity] // facility local0
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "-"
+	}
+	structured := fmt.Sprintf(
+		`[guard@0 threatType="%s" confidence="%.2f" severity="%s" action="%s" inputHash="%s"]`,
+		sdEscape(result.ThreatType), result.Confidence, sdEscape(string(result.Severity)),
+		sdEscape(string(event.Action)), sdEscape(event.InputHash))
+	return fmt.Sprintf("<%d>1 %s %s guard - - %s %s",
+		pri, event.Timestamp.Format(time.RFC3339), hostname, structured, result.Reasoning)
+}
+
+// cefEscapeHeader escapes CEF header field characters.
+func cefEscapeHeader(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// cefEscapeExt escapes CEF extension field characters.
+func cefEscapeExt(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "=", `\=`)
+	return strings.ReplaceAll(s, "\n", `\n`)
+}
+
+// sdEscape escapes RFC 5424 structured-data param values.
+func sdEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return strings.ReplaceAll(s, "]", `\]`)
+}
+
+// Exporter ships formatted events to a collector over one connection.
+type Exporter struct {
+	mu     sync.Mutex
+	conn   net.Conn
+	format Format
+}
+
+// Dial connects to a collector. network is "udp" or "tcp"; passing a
+// non-nil tlsConfig wraps a TCP connection in TLS.
+func Dial(network, addr string, format Format, tlsConfig *tls.Config) (*Exporter, error) {
+	var conn net.Conn
+	var err error
+	if tlsConfig != nil {
+		if network != "tcp" {
+			return nil, fmt.Errorf("TLS requires tcp, got %q", network)
+		}
+		conn, err = tls.Dial(network, addr, tlsConfig)
+	} else {
+		conn, err = net.Dial(network, addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Exporter{conn: conn, format: format}, nil
+}
+
+// Export formats and ships one event.
+func (e *Exporter) Export(event Event) error {
+	var line string
+	switch e.format {
+	case FormatSyslog:
+		line = FormatSyslogEvent(event)
+	default:
+		line = FormatCEFEvent(event)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, err := e.conn.Write([]byte(line + "\n"))
+	return err
+}
+
+// Close closes the collector connection.
+func (e *Exporter) Close() error {
+	return e.conn.Close()
+}
@@ -0,0 +1,88 @@
+// Package signing provides Ed25519 detached signatures for the bundles the
+// pipeline loads from disk — regex pattern files, configs, rule packs.
+// Loading arbitrary files is itself a supply-chain risk; signing lets a
+// deployment refuse bundles that were not produced by its own tooling.
+// Keys and signatures are base64-encoded so they travel in JSON and flags.
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// GenerateKeyPair returns a fresh base64-encoded Ed25519 key pair.
+func GenerateKeyPair() (privateKeyB64, publicKeyB64 string, err error) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return base64.StdEncoding.EncodeToString(privateKey),
+		base64.StdEncoding.EncodeToString(publicKey), nil
+}
+
+// Sign returns the base64 detached signature over data.
+func Sign(data []byte, privateKeyB64 string) (string, error) {
+	privateKey, err := base64.StdEncoding.DecodeString(strings.TrimSpace(privateKeyB64))
+	if err != nil {
+		return "", fmt.Errorf("invalid private key encoding: %w", err)
+	}
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return "", fmt.Errorf("private key must be %d bytes, got %d", ed25519.PrivateKeySize, len(privateKey))
+	}
+	signature := ed25519.Sign(ed25519.PrivateKey(privateKey), data)
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// Verify checks a base64 detached signature over data.
+func Verify(data []byte, signatureB64, publicKeyB64 string) error {
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(signatureB64))
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %w", err)
+	}
+	publicKey, err := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKeyB64))
+	if err != nil {
+		return fmt.Errorf("invalid public key encoding: %w", err)
+	}
+	if len(publicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("public key must be %d bytes, got %d", ed25519.PublicKeySize, len(publicKey))
+	}
+	if !ed25519.Verify(ed25519.PublicKey(publicKey), data, signature) {
+		return fmt.Errorf("signature verification failed")
+	}
+	return nil
+}
+
+// SignFile writes the detached signature for the file at path to path+".sig".
+func SignFile(path, privateKeyB64 string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	signature, err := Sign(data, privateKeyB64)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path+".sig", []byte(signature+"\n"), 0o644)
+}
+
+// VerifyFile checks the file at path against its detached path+".sig"
+// signature. A missing signature file is a verification failure: unsigned
+// bundles are refused, not waved through.
+func VerifyFile(path, publicKeyB64 string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	signature, err := os.ReadFile(path + ".sig")
+	if err != nil {
+		return fmt.Errorf("bundle %s is unsigned: %w", path, err)
+	}
+	if err := Verify(data, string(signature), publicKeyB64); err != nil {
+		return fmt.Errorf("bundle %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+// Package sysprompt is a registry for application system prompts. Instead of
+// each app keeping its prompt in code, apps register prompts here; the guard
+// versions them, uses them to detect prompt leakage in model output, and
+// alerts when a deployed prompt no longer matches what was registered
+// (possible tampering).
+package sysprompt
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/marcusjohansson/trust-go/pkg/guard"
+)
+
+// Version is one registered revision of an application's system prompt.
+type Version struct {
+	Number       int       `json:"number"`
+	Prompt       string    `json:"prompt"`
+	Hash         string    `json:"hash"`
+	RegisteredAt time.Time `json:"registered_at"`
+}
+
+// Registry stores versioned system prompts per application.
+type Registry struct {
+	mu   sync.RWMutex
+	apps map[string][]Version
+
+	// OnChange, when set, is called whenever an app registers a prompt that
+	// differs from its current one — the diff alert for unexpected changes.
+	OnChange func(app string, previous, current Version)
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{apps: map[string][]Version{}}
+}
+
+// Register stores prompt as the current version for app. Re-registering an
+// identical prompt is a no-op; a changed prompt becomes a new version and
+// fires OnChange.
+func (r *Registry) Register(app, prompt string) Version {
+	hash := hashPrompt(prompt)
+
+	r.mu.Lock()
+	history := r.apps[app]
+	if len(history) > 0 && history[len(history)-1].Hash == hash {
+		current := history[len(history)-1]
+		r.mu.Unlock()
+		return current
+	}
+	version := Version{
+		Number:       len(history) + 1,
+		Prompt:       prompt,
+		Hash:         hash,
+		RegisteredAt: time.Now(),
+	}
+	r.apps[app] = append(history, version)
+	var previous Version
+	changed := len(history) > 0
+	if changed {
+		previous = history[len(history)-1]
+	}
+	callback := r.OnChange
+	r.mu.Unlock()
+
+	if changed && callback != nil {
+		callback(app, previous, version)
+	}
+	return version
+}
+
+// Current returns the active prompt version for app.
+func (r *Registry) Current(app string) (Version, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	history := r.apps[app]
+	if len(history) == 0 {
+		return Version{}, false
+	}
+	return history[len(history)-1], true
+}
+
+// History returns all versions registered for app, oldest first.
+func (r *Registry) History(app string) []Version {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	history := make([]Version, len(r.apps[app]))
+	copy(history, r.apps[app])
+	return history
+}
+
+// Verify checks that a deployed prompt matches the registered current
+// version. A mismatch means the deployment drifted or was tampered with.
+func (r *Registry) Verify(app, deployed string) error {
+	current, ok := r.Current(app)
+	if !ok {
+		return fmt.Errorf("no registered prompt for app %q", app)
+	}
+	if hashPrompt(deployed) != current.Hash {
+		return fmt.Errorf("deployed prompt for %q does not match registered version %d",
+			app, current.Number)
+	}
+	return nil
+}
+
+// CheckLeak scans model output for leaked fragments of app's registered
+// system prompt, using word-shingle overlap so paraphrased spacing or
+// partial leaks still register.
+func (r *Registry) CheckLeak(app, output string) *guard.ThreatResult {
+	current, ok := r.Current(app)
+	if !ok {
+		return nil
+	}
+	overlap := shingleOverlap(current.Prompt, output)
+	if overlap < 0.3 {
+		return nil
+	}
+	confidence := 0.6 + overlap*0.4
+	if confidence > 0.99 {
+		confidence = 0.99
+	}
+	return &guard.ThreatResult{
+		IsThreat:   true,
+		ThreatType: "system_prompt_attack",
+		Confidence: confidence,
+		Reasoning:  fmt.Sprintf("Output shares %.0f%% of system prompt content for app %q", overlap*100, app),
+		Severity:   guard.SeverityForType("system_prompt_attack"),
+	}
+}
+
+func hashPrompt(prompt string) string {
+	sum := sha256.Sum256([]byte(prompt))
+	return hex.EncodeToString(sum[:])
+}
+
+const shingleSize = 5
+
+// shingleOverlap returns the fraction of the prompt's word 5-grams that
+// appear in the output (both lowercased and whitespace-normalized).
+func shingleOverlap(prompt, output string) float64 {
+	promptShingles := shingles(prompt)
+	if len(promptShingles) == 0 {
+		return 0
+	}
+	outputShingles := shingles(output)
+	hits := 0
+	for shingle := range promptShingles {
+		if outputShingles[shingle] {
+			hits++
+		}
+	}
+	return float64(hits) / float64(len(promptShingles))
+}
+
+func shingles(text string) map[string]bool {
+	words := strings.Fields(strings.ToLower(text))
+	set := map[string]bool{}
+	for i := 0; i+shingleSize <= len(words); i++ {
+		set[strings.Join(words[i:i+shingleSize], " ")] = true
+	}
+	return set
+}